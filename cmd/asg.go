@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/sfuruya0612/snatch/internal/util"
+	"github.com/urfave/cli/v2"
+)
+
+var asgTimeoutFlag = &cli.DurationFlag{
+	Name:  "timeout",
+	Value: 60 * time.Minute,
+	Usage: "Stop watching the progress after the duration",
+}
+
+var Asg = &cli.Command{
+	Name:  "asg",
+	Usage: "Get a list of Auto Scaling groups",
+	Action: func(c *cli.Context) error {
+		return getGroupList(c.String("profile"), c.String("region"))
+	},
+	Subcommands: []*cli.Command{
+		{
+			Name:      "describe",
+			Aliases:   []string{"d"},
+			Usage:     "Show instances with lifecycle and health, and recent scaling activities of the group",
+			ArgsUsage: "<GroupName>",
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:  "activities",
+					Value: 10,
+					Usage: "Number of scaling activities to show (1-100)",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				// MaxRecords of DescribeScalingActivities accepts 1 to 100
+				if n := c.Int("activities"); n < 1 || n > 100 {
+					return fmt.Errorf("activities must be between 1 and 100")
+				}
+				return describeGroup(c.String("profile"), c.String("region"), c.Args().First(), c.Int("activities"))
+			},
+		},
+		{
+			Name:      "desired",
+			Usage:     "Set desired capacity of the group (interactive confirmation at execute)",
+			ArgsUsage: "<GroupName> <Capacity>",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "honor-cooldown",
+					Usage: "Wait for the cooldown period to complete before changing the capacity",
+				},
+				&cli.BoolFlag{
+					Name:    "yes",
+					Aliases: []string{"y"},
+					Usage:   "Skip confirmation",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 2 {
+					return fmt.Errorf("group name and capacity are required")
+				}
+				return setDesiredCapacity(c.String("profile"), c.String("region"), c.Args().Get(0), c.Args().Get(1), c.Bool("honor-cooldown"), c.Bool("yes"))
+			},
+		},
+		{
+			Name:  "refresh",
+			Usage: "Start or watch instance refreshes of the group",
+			Subcommands: []*cli.Command{
+				{
+					Name:      "start",
+					Usage:     "Start an instance refresh (interactive confirmation at execute)",
+					ArgsUsage: "<GroupName>",
+					Flags: []cli.Flag{
+						&cli.IntFlag{
+							Name:  "min-healthy",
+							Value: 90,
+							Usage: "Percentage of the desired capacity that must remain healthy",
+						},
+						&cli.IntFlag{
+							Name:  "warmup",
+							Usage: "Seconds until a new instance is considered ready (default: group setting)",
+						},
+						&cli.BoolFlag{
+							Name:  "skip-matching",
+							Usage: "Skip instances that already have the desired configuration",
+						},
+						&cli.BoolFlag{
+							Name:    "yes",
+							Aliases: []string{"y"},
+							Usage:   "Skip confirmation",
+						},
+						&cli.BoolFlag{
+							Name:    "watch",
+							Aliases: []string{"w"},
+							Usage:   "Watch progress until the refresh completes",
+						},
+						asgTimeoutFlag,
+					},
+					Action: func(c *cli.Context) error {
+						return startInstanceRefresh(c.String("profile"), c.String("region"), c.Args().First(), c.Int("min-healthy"), c.Int("warmup"), c.Bool("skip-matching"), c.Bool("yes"), c.Bool("watch"), c.Duration("timeout"))
+					},
+				},
+				{
+					Name:      "status",
+					Usage:     "Show instance refreshes of the group",
+					ArgsUsage: "<GroupName>",
+					Flags: []cli.Flag{
+						&cli.BoolFlag{
+							Name:    "watch",
+							Aliases: []string{"w"},
+							Usage:   "Watch progress of the latest refresh until it completes",
+						},
+						asgTimeoutFlag,
+					},
+					Action: func(c *cli.Context) error {
+						return getInstanceRefreshes(c.String("profile"), c.String("region"), c.Args().First(), c.Bool("watch"), c.Duration("timeout"))
+					},
+				},
+			},
+		},
+	},
+}
+
+func getGroupList(profile, region string) error {
+	client := saws.NewAutoScalingClient(profile, region)
+
+	groups, err := client.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if err := saws.PrintGroups(os.Stdout, groups); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+// selectGroup returns the group specified by name, or the one chosen interactively when name is empty.
+func selectGroup(client *saws.AutoScaling, name string) (saws.Group, error) {
+	input := &autoscaling.DescribeAutoScalingGroupsInput{}
+	if len(name) > 0 {
+		input.AutoScalingGroupNames = []string{name}
+	}
+
+	groups, err := client.DescribeAutoScalingGroups(input)
+	if err != nil {
+		return saws.Group{}, err
+	}
+
+	if len(name) > 0 {
+		return groups[0], nil
+	}
+
+	list := []string{}
+	for _, g := range groups {
+		list = append(list, g.Name)
+	}
+
+	selected, err := util.Prompt(list, "Select Auto Scaling group")
+	if err != nil {
+		return saws.Group{}, err
+	}
+
+	for _, g := range groups {
+		if g.Name == selected {
+			return g, nil
+		}
+	}
+
+	return saws.Group{}, fmt.Errorf("group not found: %s", selected)
+}
+
+func describeGroup(profile, region, name string, activities int) error {
+	client := saws.NewAutoScalingClient(profile, region)
+
+	group, err := selectGroup(client, name)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if err := saws.PrintGroups(os.Stdout, []saws.Group{group}); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if len(group.Instances) > 0 {
+		instances, err := saws.NewEc2Client(profile, region).DescribeInstances(&ec2.DescribeInstancesInput{
+			InstanceIds: group.InstanceIds(),
+		})
+		if err != nil && !errors.Is(err, saws.ErrNoResources) {
+			return fmt.Errorf("%v", err)
+		}
+		group.JoinInstances(instances)
+
+		fmt.Println("\nInstances")
+		if err := saws.PrintGroupInstances(os.Stdout, group.Instances); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+	}
+
+	list, err := client.DescribeScalingActivities(&autoscaling.DescribeScalingActivitiesInput{
+		AutoScalingGroupName: aws.String(group.Name),
+		MaxRecords:           aws.Int32(int32(activities)),
+	})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Println("\nActivities")
+	if err := saws.PrintActivities(os.Stdout, list); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func setDesiredCapacity(profile, region, name, capacity string, honorCooldown, yes bool) error {
+	desired, err := strconv.Atoi(capacity)
+	if err != nil {
+		return fmt.Errorf("capacity must be a number: %s", capacity)
+	}
+
+	client := saws.NewAutoScalingClient(profile, region)
+
+	group, err := selectGroup(client, name)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if int32(desired) < group.MinSize || int32(desired) > group.MaxSize {
+		return fmt.Errorf("capacity %d is out of range (min: %d, max: %d)", desired, group.MinSize, group.MaxSize)
+	}
+
+	if !yes && !util.Confirm(fmt.Sprintf("desired capacity of %s %d -> %d", group.Name, group.Desired, desired)) {
+		return nil
+	}
+
+	if err := client.SetDesiredCapacity(&autoscaling.SetDesiredCapacityInput{
+		AutoScalingGroupName: aws.String(group.Name),
+		DesiredCapacity:      aws.Int32(int32(desired)),
+		HonorCooldown:        aws.Bool(honorCooldown),
+	}); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Set desired capacity of %s to %d\n", group.Name, desired)
+
+	return nil
+}
+
+func startInstanceRefresh(profile, region, name string, minHealthy, warmup int, skipMatching, yes, watch bool, timeout time.Duration) error {
+	client := saws.NewAutoScalingClient(profile, region)
+
+	group, err := selectGroup(client, name)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if !yes && !util.Confirm(fmt.Sprintf("instance refresh of %s (%d instances, min healthy %d%%)", group.Name, len(group.Instances), minHealthy)) {
+		return nil
+	}
+
+	preferences := &types.RefreshPreferences{
+		MinHealthyPercentage: aws.Int32(int32(minHealthy)),
+		SkipMatching:         aws.Bool(skipMatching),
+	}
+	if warmup > 0 {
+		preferences.InstanceWarmup = aws.Int32(int32(warmup))
+	}
+
+	id, err := client.StartInstanceRefresh(&autoscaling.StartInstanceRefreshInput{
+		AutoScalingGroupName: aws.String(group.Name),
+		Preferences:          preferences,
+	})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Started instance refresh: %s\n", id)
+
+	if !watch {
+		return nil
+	}
+
+	return watchInstanceRefresh(client, group.Name, id, timeout)
+}
+
+func getInstanceRefreshes(profile, region, name string, watch bool, timeout time.Duration) error {
+	client := saws.NewAutoScalingClient(profile, region)
+
+	group, err := selectGroup(client, name)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	refreshes, err := client.DescribeInstanceRefreshes(&autoscaling.DescribeInstanceRefreshesInput{
+		AutoScalingGroupName: aws.String(group.Name),
+	})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if len(refreshes) == 0 {
+		fmt.Println("No instance refreshes found")
+		return nil
+	}
+
+	if !watch {
+		if err := saws.PrintInstanceRefreshes(os.Stdout, refreshes); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+		return nil
+	}
+
+	return watchInstanceRefresh(client, group.Name, refreshes[0].InstanceRefreshId, timeout)
+}
+
+// watchInstanceRefresh polls the instance refresh and prints progress until it completes or the timeout passes.
+func watchInstanceRefresh(client *saws.AutoScaling, name, id string, timeout time.Duration) error {
+	start, last := time.Now(), ""
+	for {
+		refreshes, err := client.DescribeInstanceRefreshes(&autoscaling.DescribeInstanceRefreshesInput{
+			AutoScalingGroupName: aws.String(name),
+			InstanceRefreshIds:   []string{id},
+		})
+		if err != nil {
+			return fmt.Errorf("%v", err)
+		}
+
+		if len(refreshes) == 0 {
+			return fmt.Errorf("instance refresh not found: %s", id)
+		}
+
+		r := refreshes[0]
+		line := fmt.Sprintf("%s\t%s\t%d%%\t%d instances to update", r.InstanceRefreshId, r.Status, r.PercentageComplete, r.InstancesToUpdate)
+		if line != last {
+			fmt.Println(line)
+			last = line
+		}
+
+		if r.IsDone() {
+			if r.Status != string(types.InstanceRefreshStatusSuccessful) {
+				return fmt.Errorf("instance refresh %s: %s", r.Status, r.StatusReason)
+			}
+			return nil
+		}
+
+		if time.Since(start) > timeout {
+			return fmt.Errorf("timed out waiting for the instance refresh %s", id)
+		}
+
+		time.Sleep(15 * time.Second)
+	}
+}
@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmTypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
 
 	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/sfuruya0612/snatch/internal/util"
 	"github.com/urfave/cli/v2"
 )
 
@@ -62,29 +66,190 @@ var Ec2 = &cli.Command{
 				return sendCommand(c.String("profile"), c.String("region"), c.String("tag"), c.String("id"), c.String("file"), c.Args())
 			},
 		},
+		{
+			Name:      "describe",
+			Aliases:   []string{"d"},
+			Usage:     "Show details of an instance",
+			ArgsUsage: "<InstanceId|Name>",
+			Action: func(c *cli.Context) error {
+				return describeInstance(c.String("profile"), c.String("region"), c.Args().First())
+			},
+		},
+		{
+			Name:      "userdata",
+			Usage:     "Show decoded user data of an instance",
+			ArgsUsage: "<InstanceId|Name>",
+			Action: func(c *cli.Context) error {
+				return getUserData(c.String("profile"), c.String("region"), c.Args().First())
+			},
+		},
+		{
+			Name:      "console",
+			Aliases:   []string{"log"},
+			Usage:     "Show console output of an instance",
+			ArgsUsage: "[ --latest ] [ --follow | -f ] <InstanceId|Name>",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "latest",
+					Usage: "Get the most recent output (supported on Nitro instances only)",
+				},
+				&cli.BoolFlag{
+					Name:    "follow",
+					Aliases: []string{"f"},
+					Usage:   "Keep polling for new output",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return getConsoleOutput(c.String("profile"), c.String("region"), c.Args().First(), c.Bool("latest"), c.Bool("follow"))
+			},
+		},
+		securityGroupCommand,
+		volumesCommand,
+		snapshotsCommand,
+		imagesCommand,
+		launchTemplatesCommand,
+		instanceTypesCommand,
+		spotPricesCommand,
+		eipsCommand,
+		enisCommand,
+		instanceActionCommand("start", "Start instances"),
+		instanceActionCommand("stop", "Stop instances"),
+		instanceActionCommand("reboot", "Reboot instances"),
+		instanceActionCommand("terminate", "Terminate instances"),
+		instanceActionCommand("hibernate", "Hibernate instances"),
 	},
 }
 
+// instanceActionCommand returns the subcommand of an instance lifecycle action.
+func instanceActionCommand(action, usage string) *cli.Command {
+	flags := []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:    "id",
+			Aliases: []string{"i"},
+			Usage:   "Set EC2 instance id (can be specified multiple times)",
+		},
+		&cli.StringFlag{
+			Name:    "tag",
+			Aliases: []string{"t"},
+			Usage:   "Set Key-Value of the tag (e.g. -t Name:test-ec2)",
+		},
+		&cli.BoolFlag{
+			Name:    "yes",
+			Aliases: []string{"y"},
+			Usage:   "Skip confirmation",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Check permissions for the action without executing it",
+		},
+	}
+
+	// reboot does not change the instance state, so there is nothing to wait for
+	if action != "reboot" {
+		flags = append(flags, &cli.BoolFlag{
+			Name:    "wait",
+			Aliases: []string{"w"},
+			Usage:   "Wait until the instances reach the target state",
+		})
+	}
+
+	return &cli.Command{
+		Name:      action,
+		Usage:     usage + " (interactive confirmation at execute)",
+		ArgsUsage: "[ --id | -i ] <InstanceId> [ --tag | -t ] <Key:Value>",
+		Flags:     flags,
+		Action: func(c *cli.Context) error {
+			return instanceAction(c.String("profile"), c.String("region"), action, c.StringSlice("id"), c.String("tag"), c.Bool("yes"), c.Bool("dry-run"), c.Bool("wait"))
+		},
+	}
+}
+
+// parseTagFilter returns tag filter from Key:Value string.
+func parseTagFilter(tag string) (types.Filter, error) {
+	spl := strings.SplitN(tag, ":", 2)
+	if len(spl) != 2 {
+		return types.Filter{}, fmt.Errorf("tag is different (e.g. Name:hogehoge)")
+	}
+
+	return types.Filter{
+		Name:   aws.String("tag:" + spl[0]),
+		Values: []string{spl[1]},
+	}, nil
+}
+
 func getEc2List(profile, region, tag string) error {
 	input := &ec2.DescribeInstancesInput{}
 	if len(tag) > 0 {
-		if !strings.Contains(tag, ":") {
-			return fmt.Errorf("tag is different (e.g. Name:hogehoge)")
+		filter, err := parseTagFilter(tag)
+		if err != nil {
+			return err
 		}
+		input.Filters = append(input.Filters, filter)
+	}
+
+	c := saws.NewEc2Client(profile, region)
+	instances, err := c.DescribeInstances(input)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if err := saws.PrintInstances(os.Stdout, instances); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+// selectInstances returns instances specified by ids or tag,
+// or the one chosen interactively when neither is specified.
+func selectInstances(client *saws.EC2, ids []string, tag string) ([]saws.Instance, error) {
+	input := &ec2.DescribeInstancesInput{}
+
+	if len(ids) > 0 {
+		input.InstanceIds = ids
+	}
 
-		spl := strings.Split(tag, ":")
-		if len(spl) == 0 {
-			return fmt.Errorf("parse tag=%s", tag)
+	if len(tag) > 0 {
+		filter, err := parseTagFilter(tag)
+		if err != nil {
+			return nil, err
 		}
+		input.Filters = append(input.Filters, filter)
+	}
 
-		input.Filters = append(input.Filters, types.Filter{
-			Name:   aws.String("tag:" + spl[0]),
-			Values: []string{spl[1]},
-		})
+	instances, err := client.DescribeInstances(input)
+	if err != nil {
+		return nil, err
 	}
 
-	c := saws.NewEc2Client(profile, region)
-	instances, err := c.DescribeInstances(input)
+	if len(ids) > 0 || len(tag) > 0 {
+		return instances, nil
+	}
+
+	list := []string{}
+	for _, i := range instances {
+		list = append(list, i.Name+"\t"+i.InstanceId+"\t"+i.State)
+	}
+
+	selected, err := util.Prompt(list, "Select Instance")
+	if err != nil {
+		return nil, err
+	}
+	id := strings.Split(selected, "\t")[1]
+
+	for _, i := range instances {
+		if i.InstanceId == id {
+			return []saws.Instance{i}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("instance not found: %s", id)
+}
+
+func instanceAction(profile, region, action string, ids []string, tag string, yes, dryRun, wait bool) error {
+	client := saws.NewEc2Client(profile, region)
+
+	instances, err := selectInstances(client, ids, tag)
 	if err != nil {
 		return fmt.Errorf("%v", err)
 	}
@@ -93,5 +258,211 @@ func getEc2List(profile, region, tag string) error {
 		return fmt.Errorf("%v", err)
 	}
 
+	targets := []string{}
+	for _, i := range instances {
+		targets = append(targets, i.InstanceId)
+	}
+
+	if !yes && !dryRun && !util.Confirm(fmt.Sprintf("%s %d instances", action, len(targets))) {
+		return nil
+	}
+
+	var state string
+	switch action {
+	case "start":
+		err = client.StartInstances(&ec2.StartInstancesInput{InstanceIds: targets, DryRun: aws.Bool(dryRun)})
+		state = string(types.InstanceStateNameRunning)
+	case "stop":
+		err = client.StopInstances(&ec2.StopInstancesInput{InstanceIds: targets, DryRun: aws.Bool(dryRun)})
+		state = string(types.InstanceStateNameStopped)
+	case "hibernate":
+		err = client.StopInstances(&ec2.StopInstancesInput{InstanceIds: targets, DryRun: aws.Bool(dryRun), Hibernate: aws.Bool(true)})
+		state = string(types.InstanceStateNameStopped)
+	case "reboot":
+		err = client.RebootInstances(&ec2.RebootInstancesInput{InstanceIds: targets, DryRun: aws.Bool(dryRun)})
+	case "terminate":
+		err = client.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: targets, DryRun: aws.Bool(dryRun)})
+		state = string(types.InstanceStateNameTerminated)
+	default:
+		return fmt.Errorf("unsupported action %s", action)
+	}
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if dryRun {
+		fmt.Printf("Dry run succeeded: %s is permitted for %s\n", action, strings.Join(targets, ","))
+		return nil
+	}
+
+	fmt.Printf("Requested %s: %s\n", action, strings.Join(targets, ","))
+
+	if !wait || len(state) == 0 {
+		return nil
+	}
+
+	if err := client.WaitInstanceState(targets, state, 15*time.Minute); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
 	return nil
 }
+
+// resolveInstanceId returns instance id from an instance id or a Name tag.
+// When several instances have the Name, one of them is chosen interactively.
+func resolveInstanceId(client *saws.EC2, idOrName string) (string, error) {
+	if len(idOrName) == 0 {
+		return "", fmt.Errorf("instance id or name is required")
+	}
+
+	if strings.HasPrefix(idOrName, "i-") {
+		return idOrName, nil
+	}
+
+	instances, err := selectInstances(client, nil, "Name:"+idOrName)
+	if err != nil {
+		return "", err
+	}
+
+	if len(instances) == 1 {
+		return instances[0].InstanceId, nil
+	}
+
+	list := []string{}
+	for _, i := range instances {
+		list = append(list, i.Name+"\t"+i.InstanceId+"\t"+i.State)
+	}
+
+	selected, err := util.Prompt(list, "Select Instance")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Split(selected, "\t")[1], nil
+}
+
+func describeInstance(profile, region, idOrName string) error {
+	client := saws.NewEc2Client(profile, region)
+
+	id, err := resolveInstanceId(client, idOrName)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	detail, err := client.DescribeInstanceDetail(id)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	status, err := saws.NewSsmClient(profile, region).DescribeInstancePingStatus(&ssm.DescribeInstanceInformationInput{
+		Filters: []ssmTypes.InstanceInformationStringFilter{
+			{
+				Key:    aws.String("InstanceIds"),
+				Values: []string{id},
+			},
+		},
+	})
+	// the ssm status is optional detail, e.g. access to ssm may be denied
+	switch s, ok := status[id]; {
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "WARN: ssm ping status: %v\n", err)
+		detail.SsmPingStatus = "None"
+	case ok:
+		detail.SsmPingStatus = s
+	default:
+		detail.SsmPingStatus = "Not managed"
+	}
+
+	if err := saws.PrintInstanceDetail(os.Stdout, detail); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func getUserData(profile, region, idOrName string) error {
+	client := saws.NewEc2Client(profile, region)
+
+	id, err := resolveInstanceId(client, idOrName)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	data, err := client.DescribeUserData(id)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if len(data) == 0 {
+		return fmt.Errorf("no user data: %s", id)
+	}
+
+	decoded, err := util.DecodeUserData(data)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Print(decoded)
+
+	return nil
+}
+
+func getConsoleOutput(profile, region, idOrName string, latest, follow bool) error {
+	client := saws.NewEc2Client(profile, region)
+
+	id, err := resolveInstanceId(client, idOrName)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	input := &ec2.GetConsoleOutputInput{
+		InstanceId: aws.String(id),
+		Latest:     aws.Bool(latest),
+	}
+
+	prev := ""
+	for {
+		data, err := client.GetConsoleOutput(input)
+		if err != nil {
+			return fmt.Errorf("%v", err)
+		}
+
+		out, err := util.DecodeString(data)
+		if err != nil {
+			return fmt.Errorf("%v", err)
+		}
+
+		fmt.Print(newConsoleOutput(prev, out))
+		if len(out) > 0 {
+			prev = out
+		}
+
+		if !follow {
+			if len(prev) == 0 {
+				return fmt.Errorf("no console output yet: %s", id)
+			}
+			return nil
+		}
+
+		time.Sleep(10 * time.Second)
+	}
+}
+
+// newConsoleOutput returns the part of cur that was not printed in prev.
+// Console output is a sliding window, so the overlap is found by the tail of prev.
+func newConsoleOutput(prev, cur string) string {
+	if prev == cur {
+		return ""
+	}
+
+	if strings.HasPrefix(cur, prev) {
+		return cur[len(prev):]
+	}
+
+	tail := prev[max(0, len(prev)-256):]
+	if idx := strings.LastIndex(cur, tail); idx >= 0 {
+		return cur[idx+len(tail):]
+	}
+
+	return cur
+}
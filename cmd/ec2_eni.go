@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/urfave/cli/v2"
+)
+
+var eipsCommand = &cli.Command{
+	Name:  "eips",
+	Usage: "Get a list of Elastic IPs with their association (unassociated ones are flagged)",
+	Action: func(c *cli.Context) error {
+		return getAddressList(c.String("profile"), c.String("region"))
+	},
+}
+
+var enisCommand = &cli.Command{
+	Name:  "enis",
+	Usage: "Get a list of network interfaces with owner service (available ones are flagged)",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "available",
+			Usage: "Show only detached (available) network interfaces",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		return getNetworkInterfaceList(c.String("profile"), c.String("region"), c.Bool("available"))
+	},
+}
+
+func getAddressList(profile, region string) error {
+	client := saws.NewEc2Client(profile, region)
+
+	addresses, err := client.DescribeAddresses(&ec2.DescribeAddressesInput{})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if len(addresses) == 0 {
+		fmt.Println("No Elastic IPs found")
+		return nil
+	}
+
+	instances, err := client.DescribeInstances(&ec2.DescribeInstancesInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return fmt.Errorf("%v", err)
+	}
+
+	enis, err := client.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	saws.ResolveAddressAssociations(addresses, instances, enis)
+
+	if err := saws.PrintAddresses(os.Stdout, addresses); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func getNetworkInterfaceList(profile, region string, available bool) error {
+	client := saws.NewEc2Client(profile, region)
+
+	input := &ec2.DescribeNetworkInterfacesInput{}
+	if available {
+		input.Filters = []types.Filter{
+			{
+				Name:   aws.String("status"),
+				Values: []string{"available"},
+			},
+		}
+	}
+
+	enis, err := client.DescribeNetworkInterfaces(input)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if len(enis) == 0 {
+		fmt.Println("No network interfaces found")
+		return nil
+	}
+
+	if err := saws.PrintNetworkInterfaces(os.Stdout, enis); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
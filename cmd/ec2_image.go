@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/sfuruya0612/snatch/internal/util"
+	"github.com/urfave/cli/v2"
+)
+
+var imagesCommand = &cli.Command{
+	Name:      "images",
+	Usage:     "Get a list of AMIs owned by the account and their references",
+	ArgsUsage: "[ --tag | -t ] <Key:Value>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "tag",
+			Aliases: []string{"t"},
+			Usage:   "The Key-Value of the tag to filter",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		return getImageList(c.String("profile"), c.String("region"), c.String("tag"))
+	},
+	Subcommands: []*cli.Command{
+		{
+			Name:      "prune",
+			Usage:     "Deregister unreferenced AMIs and delete their snapshots (interactive confirmation at execute)",
+			ArgsUsage: "[ --older-than ] <Duration> [ --keep ] <N> [ --tag | -t ] <Key:Value>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "older-than",
+					Value: "90d",
+					Usage: "Only prune AMIs created before the duration (e.g. 90d, 2w, 48h)",
+				},
+				&cli.IntFlag{
+					Name:  "keep",
+					Value: 3,
+					Usage: "Always keep the N most recent AMIs",
+				},
+				&cli.StringFlag{
+					Name:    "tag",
+					Aliases: []string{"t"},
+					Usage:   "The Key-Value of the tag to filter",
+				},
+				&cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Only show AMIs to be pruned",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return pruneImages(c.String("profile"), c.String("region"), c.String("older-than"), c.Int("keep"), c.String("tag"), c.Bool("dry-run"))
+			},
+		},
+	},
+}
+
+// getImagesWithUsage returns AMIs owned by the account,
+// with the instances and launch templates referencing them.
+// Every lookup reads all pages, since prune deletes the images without references.
+func getImagesWithUsage(client *saws.EC2, tag string) ([]saws.Image, error) {
+	input := &ec2.DescribeImagesInput{
+		Owners: []string{"self"},
+	}
+	if len(tag) > 0 {
+		filter, err := parseTagFilter(tag)
+		if err != nil {
+			return nil, err
+		}
+		input.Filters = append(input.Filters, filter)
+	}
+
+	images, err := client.DescribeImages(input)
+	if err != nil {
+		return nil, err
+	}
+
+	used := map[string][]string{}
+
+	instances, err := client.DescribeInstances(&ec2.DescribeInstancesInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return nil, err
+	}
+	for _, i := range instances {
+		if i.State != "terminated" {
+			used[i.ImageId] = append(used[i.ImageId], "ec2:"+i.Name+"("+i.InstanceId+")")
+		}
+	}
+
+	templates, err := client.DescribeLaunchTemplates(&ec2.DescribeLaunchTemplatesInput{})
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range templates {
+		versions, err := client.DescribeLaunchTemplateVersions(&ec2.DescribeLaunchTemplateVersionsInput{
+			LaunchTemplateId: aws.String(t.LaunchTemplateId),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		seen := map[string]bool{}
+		for _, v := range versions {
+			if !seen[v.ImageId] {
+				used[v.ImageId] = append(used[v.ImageId], "lt:"+t.LaunchTemplateName)
+				seen[v.ImageId] = true
+			}
+		}
+	}
+
+	for i := range images {
+		images[i].UsedBy = used[images[i].ImageId]
+	}
+
+	return images, nil
+}
+
+func getImageList(profile, region, tag string) error {
+	client := saws.NewEc2Client(profile, region)
+
+	images, err := getImagesWithUsage(client, tag)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if err := saws.PrintImages(os.Stdout, images); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func pruneImages(profile, region, olderThan string, keep int, tag string, dryRun bool) error {
+	d, err := util.ParseDuration(olderThan)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	client := saws.NewEc2Client(profile, region)
+
+	images, err := getImagesWithUsage(client, tag)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	prune := saws.PruneImages(images, time.Now().Add(-d), keep)
+	if len(prune) == 0 {
+		fmt.Println("No AMIs to prune")
+		return nil
+	}
+
+	if err := saws.PrintImages(os.Stdout, prune); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if dryRun || !util.Confirm(fmt.Sprintf("prune %d AMIs and their snapshots", len(prune))) {
+		return nil
+	}
+
+	for _, i := range prune {
+		if err := client.DeregisterImage(&ec2.DeregisterImageInput{ImageId: aws.String(i.ImageId)}); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+		fmt.Printf("Deregistered %s\n", i.ImageId)
+
+		for _, s := range i.Snapshots {
+			if err := client.DeleteSnapshot(&ec2.DeleteSnapshotInput{SnapshotId: aws.String(s)}); err != nil {
+				return fmt.Errorf("%v", err)
+			}
+			fmt.Printf("Deleted %s\n", s)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/sfuruya0612/snatch/internal/util"
+	"github.com/urfave/cli/v2"
+)
+
+var instanceTypesCommand = &cli.Command{
+	Name:  "types",
+	Usage: "Get a list of instance types with vCPU, memory, network, EBS bandwidth and features",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "family",
+			Aliases: []string{"f"},
+			Usage:   "Set instance family prefix (e.g. m7, m7g, c6i)",
+		},
+		&cli.IntFlag{
+			Name:  "min-vcpu",
+			Usage: "Show only types with at least this many vCPUs",
+		},
+		&cli.StringFlag{
+			Name:  "arch",
+			Usage: "Set architecture (x86_64 | arm64)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		return getInstanceTypeList(c.String("profile"), c.String("region"), c.String("family"), c.Int("min-vcpu"), c.String("arch"))
+	},
+}
+
+var spotPricesCommand = &cli.Command{
+	Name:  "spot-prices",
+	Usage: "Show spot price history per AZ with min, avg and max over a window",
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:     "type",
+			Aliases:  []string{"t"},
+			Required: true,
+			Usage:    "Set instance type (can be specified multiple times)",
+		},
+		&cli.StringFlag{
+			Name:  "window",
+			Value: "7d",
+			Usage: "Set period of the history (e.g. 24h, 7d, 4w)",
+		},
+		&cli.StringFlag{
+			Name:  "product",
+			Value: "Linux/UNIX",
+			Usage: "Set product description (e.g. Linux/UNIX, Windows)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		return getSpotPrices(c.String("profile"), c.String("region"), c.StringSlice("type"), c.String("window"), c.String("product"))
+	},
+}
+
+func getInstanceTypeList(profile, region, family string, minVcpu int, arch string) error {
+	input := &ec2.DescribeInstanceTypesInput{}
+
+	if len(family) > 0 {
+		input.Filters = append(input.Filters, types.Filter{
+			Name:   aws.String("instance-type"),
+			Values: []string{family + "*"},
+		})
+	}
+
+	if len(arch) > 0 {
+		input.Filters = append(input.Filters, types.Filter{
+			Name:   aws.String("processor-info.supported-architecture"),
+			Values: []string{arch},
+		})
+	}
+
+	client := saws.NewEc2Client(profile, region)
+
+	specs, err := client.DescribeInstanceTypes(input)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	list := []saws.InstanceTypeSpec{}
+	for _, s := range specs {
+		if int(s.VCpus) >= minVcpu {
+			list = append(list, s)
+		}
+	}
+
+	if len(list) == 0 {
+		fmt.Println("No instance types found")
+		return nil
+	}
+
+	if err := saws.PrintInstanceTypes(os.Stdout, list); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func getSpotPrices(profile, region string, instanceTypes []string, window, product string) error {
+	d, err := util.ParseDuration(window)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	input := &ec2.DescribeSpotPriceHistoryInput{
+		StartTime:           aws.Time(time.Now().Add(-d)),
+		EndTime:             aws.Time(time.Now()),
+		ProductDescriptions: []string{product},
+	}
+	for _, t := range instanceTypes {
+		input.InstanceTypes = append(input.InstanceTypes, types.InstanceType(t))
+	}
+
+	client := saws.NewEc2Client(profile, region)
+
+	prices, err := client.DescribeSpotPriceHistory(input)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if len(prices) == 0 {
+		fmt.Println("No spot prices found")
+		return nil
+	}
+
+	if err := saws.PrintSpotPriceStats(os.Stdout, saws.SummarizeSpotPrices(prices)); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
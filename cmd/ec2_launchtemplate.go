@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/sfuruya0612/snatch/internal/util"
+	"github.com/urfave/cli/v2"
+)
+
+var launchTemplatesCommand = &cli.Command{
+	Name:    "launch-templates",
+	Aliases: []string{"lt"},
+	Usage:   "Get a list of launch templates with default and latest versions",
+	Action: func(c *cli.Context) error {
+		return getLaunchTemplateList(c.String("profile"), c.String("region"))
+	},
+	Subcommands: []*cli.Command{
+		{
+			Name:      "show",
+			Usage:     "Show resolved data of a launch template version with decoded user data",
+			ArgsUsage: "[ --version | -v ] <Version> <TemplateName>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "version",
+					Aliases: []string{"v"},
+					Value:   "$Default",
+					Usage:   "Set version number, $Latest or $Default",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 1 {
+					return fmt.Errorf("template name is required")
+				}
+				return showLaunchTemplate(c.String("profile"), c.String("region"), c.Args().First(), c.String("version"))
+			},
+		},
+		{
+			Name:      "diff",
+			Usage:     "Show differences between two versions of a launch template",
+			ArgsUsage: "<TemplateName> <Version> <Version>",
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 3 {
+					return fmt.Errorf("template name and two versions are required")
+				}
+				return diffLaunchTemplate(c.String("profile"), c.String("region"), c.Args().Get(0), c.Args().Get(1), c.Args().Get(2))
+			},
+		},
+	},
+}
+
+func getLaunchTemplateList(profile, region string) error {
+	client := saws.NewEc2Client(profile, region)
+
+	templates, err := client.DescribeLaunchTemplates(&ec2.DescribeLaunchTemplatesInput{})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if len(templates) == 0 {
+		fmt.Println("No launch templates found")
+		return nil
+	}
+
+	if err := saws.PrintLaunchTemplates(os.Stdout, templates); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func showLaunchTemplate(profile, region, name, version string) error {
+	client := saws.NewEc2Client(profile, region)
+
+	detail, err := client.DescribeLaunchTemplateVersionDetail(name, version)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if err := saws.PrintLaunchTemplateVersionDetail(os.Stdout, detail); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func diffLaunchTemplate(profile, region, name, from, to string) error {
+	client := saws.NewEc2Client(profile, region)
+
+	a, err := client.DescribeLaunchTemplateVersionDetail(name, from)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	b, err := client.DescribeLaunchTemplateVersionDetail(name, to)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	changes := util.DiffMap(a.Data, b.Data)
+	if len(changes) == 0 && a.UserData == b.UserData {
+		fmt.Printf("No differences between version %s and %s\n", a.Version, b.Version)
+		return nil
+	}
+
+	if len(changes) > 0 {
+		if err := util.PrintChanges(os.Stdout, changes, "Version "+a.Version, "Version "+b.Version); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+	}
+
+	if a.UserData != b.UserData {
+		fmt.Println("\nUserData")
+		fmt.Println(strings.Join(util.DiffLines(a.UserData, b.UserData), "\n"))
+	}
+
+	return nil
+}
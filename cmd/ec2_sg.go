@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/urfave/cli/v2"
+)
+
+var securityGroupCommand = &cli.Command{
+	Name:      "sg",
+	Usage:     "Get a list of security groups with their rules",
+	ArgsUsage: "[ --vpc ] <VpcId>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "vpc",
+			Usage: "Set VPC id to filter",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		return getSecurityGroupList(c.String("profile"), c.String("region"), c.String("vpc"))
+	},
+	Subcommands: []*cli.Command{
+		{
+			Name:  "unused",
+			Usage: "Get a list of security groups not attached to any network interface",
+			Action: func(c *cli.Context) error {
+				return getUnusedSecurityGroups(c.String("profile"), c.String("region"))
+			},
+		},
+		{
+			Name:  "audit",
+			Usage: "Find rules open to the world (0.0.0.0/0, ::/0) on sensitive ports",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "all",
+					Usage: "Report world-open rules on any port",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return auditSecurityGroups(c.String("profile"), c.String("region"), c.Bool("all"))
+			},
+		},
+	},
+}
+
+func getSecurityGroupList(profile, region, vpc string) error {
+	input := &ec2.DescribeSecurityGroupsInput{}
+	if len(vpc) > 0 {
+		input.Filters = append(input.Filters, types.Filter{
+			Name:   aws.String("vpc-id"),
+			Values: []string{vpc},
+		})
+	}
+
+	client := saws.NewEc2Client(profile, region)
+	groups, err := client.DescribeSecurityGroups(input)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if err := saws.PrintSecurityGroups(os.Stdout, groups); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func getUnusedSecurityGroups(profile, region string) error {
+	client := saws.NewEc2Client(profile, region)
+
+	groups, err := client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	enis, err := client.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	used := map[string]bool{}
+	for _, n := range enis {
+		for _, g := range n.SecurityGroups {
+			used[g] = true
+		}
+	}
+
+	// default groups can not be deleted, so they are never reported
+	unused := []saws.SecurityGroup{}
+	for _, g := range groups {
+		if !used[g.GroupId] && g.GroupName != "default" {
+			unused = append(unused, g)
+		}
+	}
+
+	if err := saws.PrintUnusedSecurityGroups(os.Stdout, unused); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func auditSecurityGroups(profile, region string, all bool) error {
+	client := saws.NewEc2Client(profile, region)
+
+	groups, err := client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	attachments, err := getSecurityGroupAttachments(profile, region)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	findings := saws.AuditSecurityGroups(groups, attachments, all)
+	if len(findings) == 0 {
+		fmt.Println("No world-open rules found")
+		return nil
+	}
+
+	if err := saws.PrintSecurityGroupFindings(os.Stdout, findings); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+// getSecurityGroupAttachments returns map of security group id to the instances,
+// RDS instances, load balancers and other network interfaces the group is attached to.
+func getSecurityGroupAttachments(profile, region string) (map[string][]string, error) {
+	attachments := map[string][]string{}
+	attach := func(groups []string, resource string) {
+		for _, g := range groups {
+			attachments[g] = append(attachments[g], resource)
+		}
+	}
+
+	instances, err := saws.NewEc2Client(profile, region).DescribeInstances(&ec2.DescribeInstancesInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return nil, err
+	}
+	for _, i := range instances {
+		attach(i.SecurityGroups, "ec2:"+i.Name+"("+i.InstanceId+")")
+	}
+
+	dbs, err := saws.NewRdsClient(profile, region).DescribeDBInstances(&rds.DescribeDBInstancesInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return nil, err
+	}
+	for _, d := range dbs {
+		attach(d.SecurityGroups, "rds:"+d.Name)
+	}
+
+	lbs, err := saws.NewElbClient(profile, region).DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return nil, err
+	}
+	lbv2, err := saws.NewElbV2Client(profile, region).DescribeLoadBalancersV2(&elbv2.DescribeLoadBalancersInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return nil, err
+	}
+	for _, l := range append(lbs, lbv2...) {
+		attach(l.SecurityGroups, "elb:"+l.Name)
+	}
+
+	enis, err := saws.NewEc2Client(profile, region).DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{})
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range enis {
+		if n.InstanceId != "None" || n.RequesterId == "amazon-rds" || n.RequesterId == "amazon-elb" {
+			continue
+		}
+		attach(n.SecurityGroups, "eni:"+n.NetworkInterfaceId+"("+n.Description+")")
+	}
+
+	return attachments, nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/urfave/cli/v2"
+)
+
+var volumesCommand = &cli.Command{
+	Name:  "volumes",
+	Usage: "Get a list of EBS volumes",
+	Action: func(c *cli.Context) error {
+		return getVolumeList(c.String("profile"), c.String("region"))
+	},
+	Subcommands: []*cli.Command{
+		{
+			Name:  "waste",
+			Usage: "Report unattached volumes, orphaned snapshots and gp2 volumes with estimated monthly cost",
+			Action: func(c *cli.Context) error {
+				return getEbsWaste(c.String("profile"), c.String("region"))
+			},
+		},
+	},
+}
+
+var snapshotsCommand = &cli.Command{
+	Name:  "snapshots",
+	Usage: "Get a list of EBS snapshots owned by the account",
+	Action: func(c *cli.Context) error {
+		return getSnapshotList(c.String("profile"), c.String("region"))
+	},
+}
+
+func getVolumeList(profile, region string) error {
+	client := saws.NewEc2Client(profile, region)
+
+	volumes, err := client.DescribeVolumes(&ec2.DescribeVolumesInput{})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	instances, err := client.DescribeInstances(&ec2.DescribeInstancesInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return fmt.Errorf("%v", err)
+	}
+
+	names := map[string]string{}
+	for _, i := range instances {
+		names[i.InstanceId] = i.Name
+	}
+
+	for i := range volumes {
+		volumes[i].InstanceName = names[volumes[i].InstanceId]
+	}
+
+	if err := saws.PrintVolumes(os.Stdout, volumes); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func getSnapshotList(profile, region string) error {
+	client := saws.NewEc2Client(profile, region)
+
+	snapshots, err := client.DescribeSnapshots(&ec2.DescribeSnapshotsInput{
+		OwnerIds: []string{"self"},
+	})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if err := saws.PrintSnapshots(os.Stdout, snapshots); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func getEbsWaste(profile, region string) error {
+	client := saws.NewEc2Client(profile, region)
+
+	volumes, err := client.DescribeVolumes(&ec2.DescribeVolumesInput{})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	snapshots, err := client.DescribeSnapshots(&ec2.DescribeSnapshotsInput{
+		OwnerIds: []string{"self"},
+	})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	images, err := client.DescribeImages(&ec2.DescribeImagesInput{
+		Owners: []string{"self"},
+	})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	waste := saws.EbsWaste(volumes, snapshots, images)
+	if len(waste) == 0 {
+		fmt.Println("No waste found")
+		return nil
+	}
+
+	fmt.Println("Estimated with us-east-1 prices. gp2->gp3 rows show savings, others show the current cost.")
+
+	if err := saws.PrintWaste(os.Stdout, waste); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
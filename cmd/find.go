@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/urfave/cli/v2"
+)
+
+var Find = &cli.Command{
+	Name:      "find",
+	Usage:     "Search an IP, DNS name or ID across EC2, ENI, RDS, ElastiCache, ELB, Route53, S3 and CloudFormation",
+	ArgsUsage: "<Term>",
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return fmt.Errorf("search term is required")
+		}
+		return find(c.String("profile"), c.String("region"), c.Args().First())
+	},
+}
+
+// finder returns matches of term in a service.
+type finder func(profile, region, term string) ([]saws.Match, error)
+
+var finders = map[string]finder{
+	"ec2":            findEc2,
+	"eni":            findEni,
+	"rds":            findRds,
+	"elasticache":    findElastiCache,
+	"elb":            findElb,
+	"route53":        findRoute53,
+	"s3":             findS3,
+	"cloudformation": findCloudFormation,
+}
+
+// find runs all finders concurrently.
+// A failed service (e.g. access denied) is reported and does not stop the others.
+func find(profile, region, term string) error {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		matches = []saws.Match{}
+		errs    = []string{}
+	)
+
+	for name, f := range finders {
+		wg.Add(1)
+		go func(name string, f finder) {
+			defer wg.Done()
+
+			m, err := f(profile, region, term)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil && !errors.Is(err, saws.ErrNoResources) {
+				errs = append(errs, name+": "+err.Error())
+			}
+			matches = append(matches, m...)
+		}(name, f)
+	}
+	wg.Wait()
+
+	sort.Strings(errs)
+	for _, e := range errs {
+		fmt.Fprintf(os.Stderr, "WARN: %s\n", e)
+	}
+
+	if len(matches) == 0 {
+		fmt.Printf("No resources found for %s\n", term)
+		return nil
+	}
+
+	saws.SortMatches(matches)
+
+	if err := saws.PrintMatches(os.Stdout, matches); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+// field returns empty for the "None" placeholder of missing values, so MatchFields skips it.
+func field(v string) string {
+	if v == "None" {
+		return ""
+	}
+	return v
+}
+
+func findEc2(profile, region, term string) ([]saws.Match, error) {
+	instances, err := saws.NewEc2Client(profile, region).DescribeInstances(&ec2.DescribeInstancesInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []saws.Match{}
+	for _, i := range instances {
+		matches = append(matches, saws.MatchFields(term, "ec2", i.Name+" ("+i.InstanceId+")",
+			saws.Exact("InstanceId", i.InstanceId),
+			saws.Exact("Name", i.Name),
+			saws.Exact("PrivateIP", field(i.PrivateIpAddress)),
+			saws.Exact("PublicIP", field(i.PublicIpAddress)),
+		)...)
+	}
+
+	return matches, nil
+}
+
+func findEni(profile, region, term string) ([]saws.Match, error) {
+	enis, err := saws.NewEc2Client(profile, region).DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []saws.Match{}
+	for _, n := range enis {
+		matches = append(matches, saws.MatchFields(term, "eni", n.NetworkInterfaceId+" ("+n.OwnerService()+")",
+			saws.Exact("NetworkInterfaceId", n.NetworkInterfaceId),
+			saws.Exact("PrivateIPs", strings.Join(n.PrivateIps, ",")),
+			saws.Exact("PublicIPs", strings.Join(n.PublicIps, ",")),
+			saws.Substring("Description", field(n.Description)),
+		)...)
+	}
+
+	return matches, nil
+}
+
+func findRds(profile, region, term string) ([]saws.Match, error) {
+	client := saws.NewRdsClient(profile, region)
+
+	matches := []saws.Match{}
+
+	instances, err := client.DescribeDBInstances(&rds.DescribeDBInstancesInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return nil, err
+	}
+	for _, i := range instances {
+		matches = append(matches, saws.MatchFields(term, "rds", i.Name,
+			saws.Exact("DBInstanceIdentifier", i.Name),
+			saws.Substring("Endpoint", field(i.Endpoint)),
+		)...)
+	}
+
+	clusters, err := client.DescribeDBClusters(&rds.DescribeDBClustersInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return nil, err
+	}
+	for _, c := range clusters {
+		matches = append(matches, saws.MatchFields(term, "rds", c.Name,
+			saws.Exact("DBClusterIdentifier", c.Name),
+			saws.Substring("Endpoint", field(c.Endpoint)),
+			saws.Substring("ReaderEndpoint", field(c.ReaderEndpoint)),
+		)...)
+	}
+
+	return matches, nil
+}
+
+func findElastiCache(profile, region, term string) ([]saws.Match, error) {
+	client := saws.NewElastiCacheClient(profile, region)
+
+	nodes, err := client.DescribeCacheClusters(&elasticache.DescribeCacheClustersInput{
+		ShowCacheNodeInfo: aws.Bool(true),
+	})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return nil, err
+	}
+
+	matches := []saws.Match{}
+	for _, n := range nodes {
+		matches = append(matches, saws.MatchFields(term, "elasticache", n.CacheClusterId,
+			saws.Exact("CacheClusterId", n.CacheClusterId),
+			saws.Substring("Endpoint", strings.Join(n.Endpoints, ",")),
+		)...)
+	}
+
+	groups, err := client.ListReplicationGroupEndpoints(&elasticache.DescribeReplicationGroupsInput{})
+	if err != nil {
+		return nil, err
+	}
+	for id, endpoints := range groups {
+		matches = append(matches, saws.MatchFields(term, "elasticache", id,
+			saws.Exact("ReplicationGroupId", id),
+			saws.Substring("Endpoint", strings.Join(endpoints, ",")),
+		)...)
+	}
+
+	return matches, nil
+}
+
+func findElb(profile, region, term string) ([]saws.Match, error) {
+	classic, err := saws.NewElbClient(profile, region).DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return nil, err
+	}
+
+	balancers, err := saws.NewElbV2Client(profile, region).DescribeLoadBalancersV2(&elbv2.DescribeLoadBalancersInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return nil, err
+	}
+
+	matches := []saws.Match{}
+	for _, b := range append(classic, balancers...) {
+		matches = append(matches, saws.MatchFields(term, "elb", b.Name+" ("+b.Type+")",
+			saws.Exact("Name", b.Name),
+			saws.Substring("DNSName", b.DNSName),
+		)...)
+	}
+
+	return matches, nil
+}
+
+func findRoute53(profile, region, term string) ([]saws.Match, error) {
+	records, err := saws.NewRoute53Client(profile, region).ListHostedZones(&route53.ListHostedZonesInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []saws.Match{}
+	for _, r := range records {
+		matches = append(matches, saws.MatchFields(term, "route53", r.DomainName+" "+r.Type,
+			saws.Substring("Name", r.DomainName),
+			saws.Substring("Value", r.DomainValue),
+		)...)
+	}
+
+	return matches, nil
+}
+
+func findS3(profile, region, term string) ([]saws.Match, error) {
+	buckets, err := saws.NewS3Client(profile, region).ListBuckets(&s3.ListBucketsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []saws.Match{}
+	for _, b := range buckets {
+		matches = append(matches, saws.MatchFields(term, "s3", b, saws.Exact("Bucket", b))...)
+	}
+
+	return matches, nil
+}
+
+// findCloudFormation searches physical resource ids of all stacks,
+// listing the resources of at most 5 stacks at a time.
+func findCloudFormation(profile, region, term string) ([]saws.Match, error) {
+	client := saws.NewCfnClient(profile, region)
+
+	stacks, err := client.DescribeStacks(&cloudformation.DescribeStacksInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, 5)
+		matches = []saws.Match{}
+		errs    = []error{}
+	)
+
+	for _, s := range stacks {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			resources, err := client.ListStackResources(&cloudformation.ListStackResourcesInput{
+				StackName: aws.String(name),
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+
+			for _, r := range resources {
+				matches = append(matches, saws.MatchFields(term, "cloudformation", r.StackName+"/"+r.LogicalResourceId+" ("+r.ResourceType+")",
+					saws.Exact("PhysicalResourceId", field(r.PhysicalResourceId)),
+				)...)
+			}
+		}(s.Name)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return matches, errs[0]
+	}
+
+	return matches, nil
+}
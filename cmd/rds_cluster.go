@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/sfuruya0612/snatch/internal/mapping"
+	"github.com/sfuruya0612/snatch/internal/util"
+	"github.com/urfave/cli/v2"
+)
+
+var rdsClusterCommand = &cli.Command{
+	Name:      "cluster",
+	Aliases:   []string{"c"},
+	Usage:     "Show RDS clusters as a tree of members, endpoints and replica lag",
+	ArgsUsage: "[ --list | -l ] [ClusterName]",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "list",
+			Aliases: []string{"l"},
+			Usage:   "Get a list of RDS cluster as a table",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if c.Bool("list") {
+			return getRdsClusterList(c.String("profile"), c.String("region"))
+		}
+		return getRdsClusterTopology(c.String("profile"), c.String("region"), c.Args().First())
+	},
+	Subcommands: []*cli.Command{
+		{
+			Name:    "endpoint",
+			Aliases: []string{"e"},
+			Usage:   "Get a list of RDS cluster endpoint",
+			Action: func(c *cli.Context) error {
+				return getRdsClusterEndpoints(c.String("profile"), c.String("region"))
+			},
+		},
+		{
+			Name:      "failover",
+			Usage:     "Fail over a cluster to a reader and watch status and events (interactive confirmation at execute)",
+			ArgsUsage: "[ --target <DBInstanceIdentifier> ] <DBClusterIdentifier>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "target",
+					Usage: "Set reader instance to promote (default: selected by RDS)",
+				},
+				rdsNoWaitFlag,
+				rdsTimeoutFlag,
+				rdsYesFlag,
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 1 {
+					return fmt.Errorf("db cluster identifier is required")
+				}
+				return failoverRdsCluster(c.String("profile"), c.String("region"), c.Args().First(), c.String("target"), !c.Bool("no-wait"), c.Duration("timeout"), c.Bool("yes"))
+			},
+		},
+	},
+}
+
+func getRdsClusterList(profile, region string) error {
+	c := saws.NewRdsClient(profile, region)
+
+	clusters, err := c.DescribeDBClusters(&rds.DescribeDBClustersInput{})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if err := saws.PrintDBClusters(os.Stdout, clusters); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func getRdsClusterEndpoints(profile, region string) error {
+	c := saws.NewRdsClient(profile, region)
+
+	endpoints, err := c.DescribeDBClusterEndpoints(&rds.DescribeDBClusterEndpointsInput{})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if err := saws.PrintDBClusterEndpoints(os.Stdout, endpoints); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func getRdsClusterTopology(profile, region, name string) error {
+	client := saws.NewRdsClient(profile, region)
+
+	input := &rds.DescribeDBClustersInput{}
+	if name != "" {
+		input.DBClusterIdentifier = aws.String(name)
+	}
+
+	clusters, err := client.DescribeDBClusters(input)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	instances, err := client.DescribeDBInstances(&rds.DescribeDBInstancesInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return fmt.Errorf("%v", err)
+	}
+
+	members := map[string]saws.DBInstance{}
+	for _, i := range instances {
+		members[i.Name] = i
+	}
+
+	endpoints, err := client.DescribeDBClusterEndpoints(&rds.DescribeDBClusterEndpointsInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return fmt.Errorf("%v", err)
+	}
+
+	lag := newReplicaLag(profile, region)
+
+	for _, c := range clusters {
+		root := util.NewTree(c.Name + " (" + c.Status + ")")
+		root.Add("engine: " + c.Engine + " " + c.EngineVersion + " (" + c.EngineMode + ")")
+		if c.ServerlessV2 != "None" {
+			root.Add("serverless v2: " + c.ServerlessV2)
+		}
+
+		in := root.Add("instances")
+		for _, m := range c.Members {
+			role := "reader"
+			if m.IsWriter {
+				role = "writer"
+			}
+
+			label := role + ": " + m.Name
+			if i, ok := members[m.Name]; ok {
+				label += " " + i.DBInstanceClass + " " + i.AvailabilityZone + " " + i.DBInstanceStatus
+			}
+			label += " tier " + strconv.Itoa(int(m.PromotionTier))
+
+			if !m.IsWriter {
+				label += " lag " + lag.get(m.Name)
+			}
+
+			in.Add(label)
+		}
+
+		en := root.Add("endpoints")
+		port := ":" + strconv.Itoa(int(c.Port))
+		if c.Endpoint != "None" {
+			en.Add("writer: " + c.Endpoint + port)
+		}
+		if c.ReaderEndpoint != "None" {
+			en.Add("reader: " + c.ReaderEndpoint + port)
+		}
+		for _, e := range endpoints {
+			if e.DBClusterIdentifier != c.Name || e.EndpointType != "CUSTOM" {
+				continue
+			}
+
+			label := "custom " + strings.ToLower(e.CustomEndpointType) + ": " + e.Endpoint + port + " " + e.Status
+			if len(e.StaticMembers) > 0 {
+				label += " [" + strings.Join(e.StaticMembers, ",") + "]"
+			}
+			en.Add(label)
+		}
+
+		if err := root.Print(os.Stdout); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+	}
+
+	if lag.err != nil {
+		fmt.Fprintf(os.Stderr, "WARN: replica lag: %v\n", lag.err)
+	}
+
+	return nil
+}
+
+// replicaLag fetches the latest AuroraReplicaLag of reader instances.
+// A CloudWatch error (e.g. access denied) is kept and the lag is shown as unknown.
+type replicaLag struct {
+	client *saws.CloudWatch
+	info   mapping.MetricInfo
+	err    error
+}
+
+func newReplicaLag(profile, region string) *replicaLag {
+	return &replicaLag{
+		client: saws.NewCloudWatchClient(profile, region),
+		info:   mapping.MetricsMap["rds"],
+	}
+}
+
+func (r *replicaLag) get(instance string) string {
+	if r.err != nil {
+		return "unknown"
+	}
+
+	statistic := "Average"
+	for _, d := range r.info.MetricDetails {
+		if d.MetricName == "AuroraReplicaLag" {
+			statistic = d.Statistics
+		}
+	}
+
+	now := time.Now()
+	points, err := r.client.GetMetricStatistics(&cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String(r.info.Namespace),
+		MetricName: aws.String("AuroraReplicaLag"),
+		Dimensions: []cwtypes.Dimension{
+			{
+				Name:  aws.String("DBInstanceIdentifier"),
+				Value: aws.String(instance),
+			},
+		},
+		StartTime:  aws.Time(now.Add(-10 * time.Minute)),
+		EndTime:    aws.Time(now),
+		Period:     aws.Int32(int32(r.info.Period)),
+		Statistics: []cwtypes.Statistic{cwtypes.Statistic(statistic)},
+	})
+	if err != nil {
+		r.err = err
+		return "unknown"
+	}
+
+	if len(points) == 0 {
+		return "None"
+	}
+
+	return strconv.FormatFloat(points[len(points)-1].Value, 'f', 1, 64) + "ms"
+}
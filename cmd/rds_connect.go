@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/sfuruya0612/snatch/internal/util"
+	"github.com/urfave/cli/v2"
+)
+
+var rdsUserFlag = &cli.StringFlag{
+	Name:     "user",
+	Aliases:  []string{"u"},
+	Required: true,
+	Usage:    "Set database user which is granted IAM authentication",
+}
+
+var rdsTokenCommand = &cli.Command{
+	Name:      "token",
+	Usage:     "Print an IAM database authentication token signed with the session credentials",
+	ArgsUsage: "--user <User> <DBInstanceIdentifier>",
+	Flags: []cli.Flag{
+		rdsUserFlag,
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return fmt.Errorf("db instance identifier is required")
+		}
+		return printRdsAuthToken(c.String("profile"), c.String("region"), c.Args().First(), c.String("user"))
+	},
+}
+
+var rdsConnectCommand = &cli.Command{
+	Name:      "connect",
+	Usage:     "Connect to a DB instance with psql or mysql using IAM database authentication over SSL",
+	ArgsUsage: "--user <User> [ --database | -d ] <Database> [ --ca-bundle ] <Path> <DBInstanceIdentifier>",
+	Flags: []cli.Flag{
+		rdsUserFlag,
+		&cli.StringFlag{
+			Name:    "database",
+			Aliases: []string{"d"},
+			Usage:   "Set database name",
+		},
+		&cli.StringFlag{
+			Name:  "ca-bundle",
+			Usage: "Set path of the RDS CA bundle to verify the server certificate (otherwise SSL is required without verification)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return fmt.Errorf("db instance identifier is required")
+		}
+		return connectRds(c.String("profile"), c.String("region"), c.Args().First(), c.String("user"), c.String("database"), c.String("ca-bundle"))
+	},
+}
+
+// getRdsAuthInstance returns the db instance with IAM database authentication enabled.
+func getRdsAuthInstance(client *saws.RDS, id string) (saws.DBInstance, error) {
+	instances, err := client.DescribeDBInstances(&rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(id),
+	})
+	if err != nil {
+		return saws.DBInstance{}, err
+	}
+
+	i := instances[0]
+	if i.Endpoint == "None" {
+		return saws.DBInstance{}, fmt.Errorf("%s has no endpoint (%s)", id, i.DBInstanceStatus)
+	}
+
+	if !i.IAMAuthEnabled {
+		return saws.DBInstance{}, fmt.Errorf("IAM database authentication is not enabled on %s", id)
+	}
+
+	return i, nil
+}
+
+func printRdsAuthToken(profile, region, id, user string) error {
+	client := saws.NewRdsClient(profile, region)
+
+	i, err := getRdsAuthInstance(client, id)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	token, err := client.BuildAuthToken(i.Endpoint, user)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Println(token)
+
+	return nil
+}
+
+func connectRds(profile, region, id, user, database, caBundle string) error {
+	client := saws.NewRdsClient(profile, region)
+
+	i, err := getRdsAuthInstance(client, id)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	host, port, err := net.SplitHostPort(i.Endpoint)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	token, err := client.BuildAuthToken(i.Endpoint, user)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	// the token is passed by environment variable so that it is not shown in the process list
+	var process string
+	var args []string
+	switch {
+	case strings.Contains(i.Engine, "postgres"):
+		if len(database) == 0 {
+			database = "postgres"
+		}
+
+		conn := []string{"host=" + host, "port=" + port, "user=" + user, "dbname=" + database}
+		if len(caBundle) > 0 {
+			conn = append(conn, "sslmode=verify-full", "sslrootcert="+caBundle)
+		} else {
+			conn = append(conn, "sslmode=require")
+		}
+
+		process, args = "psql", []string{strings.Join(conn, " ")}
+		if err := os.Setenv("PGPASSWORD", token); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+	case strings.Contains(i.Engine, "mysql"), i.Engine == "mariadb":
+		args = []string{"--host=" + host, "--port=" + port, "--user=" + user, "--enable-cleartext-plugin"}
+		if len(caBundle) > 0 {
+			args = append(args, "--ssl-mode=VERIFY_IDENTITY", "--ssl-ca="+caBundle)
+		} else {
+			args = append(args, "--ssl-mode=REQUIRED")
+		}
+		if len(database) > 0 {
+			args = append(args, database)
+		}
+
+		process = "mysql"
+		if err := os.Setenv("MYSQL_PWD", token); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+	default:
+		return fmt.Errorf("unsupported engine %s", i.Engine)
+	}
+
+	if err := util.ExecCommand(process, args...); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
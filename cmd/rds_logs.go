@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/sfuruya0612/snatch/internal/util"
+	"github.com/urfave/cli/v2"
+)
+
+var rdsLogsCommand = &cli.Command{
+	Name:      "logs",
+	Aliases:   []string{"l"},
+	Usage:     "Get a list of log files of a DB instance, or show a log file",
+	ArgsUsage: "[ --since ] <Duration> [ --grep ] <Pattern> [ --follow | -f ] <DBInstanceIdentifier> [LogFileName]",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "since",
+			Usage: "Show log files written and lines logged within the period (e.g. 30m, 3h, 2d)",
+		},
+		&cli.StringFlag{
+			Name:  "grep",
+			Usage: "Show lines matching the regular expression",
+		},
+		&cli.BoolFlag{
+			Name:    "follow",
+			Aliases: []string{"f"},
+			Usage:   "Keep polling for new lines",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() < 1 || c.NArg() > 2 {
+			return fmt.Errorf("db instance identifier is required")
+		}
+
+		var since time.Time
+		if len(c.String("since")) > 0 {
+			d, err := util.ParseDuration(c.String("since"))
+			if err != nil {
+				return fmt.Errorf("%v", err)
+			}
+			since = time.Now().Add(-d)
+		}
+
+		if c.NArg() == 1 {
+			return getRdsLogFiles(c.String("profile"), c.String("region"), c.Args().First(), since)
+		}
+
+		filter := &saws.DBLogFilter{Since: since}
+		if len(c.String("grep")) > 0 {
+			re, err := regexp.Compile(c.String("grep"))
+			if err != nil {
+				return fmt.Errorf("%v", err)
+			}
+			filter.Pattern = re
+		}
+
+		return showRdsLogFile(c.String("profile"), c.String("region"), c.Args().Get(0), c.Args().Get(1), filter, c.Bool("follow"))
+	},
+}
+
+func getRdsLogFiles(profile, region, db string, since time.Time) error {
+	client := saws.NewRdsClient(profile, region)
+
+	input := &rds.DescribeDBLogFilesInput{
+		DBInstanceIdentifier: aws.String(db),
+	}
+	if !since.IsZero() {
+		input.FileLastWritten = aws.Int64(since.UnixMilli())
+	}
+
+	files, err := client.DescribeDBLogFiles(input)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if len(files) == 0 {
+		fmt.Println("No log files found")
+		return nil
+	}
+
+	if err := saws.PrintDBLogFiles(os.Stdout, files); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+// showRdsLogFile downloads the log file from the beginning with markers.
+// With follow, it keeps polling from the last marker like tail -f.
+func showRdsLogFile(profile, region, db, file string, filter *saws.DBLogFilter, follow bool) error {
+	client := saws.NewRdsClient(profile, region)
+
+	input := &rds.DownloadDBLogFilePortionInput{
+		DBInstanceIdentifier: aws.String(db),
+		LogFileName:          aws.String(file),
+		Marker:               aws.String("0"),
+	}
+
+	// a portion may end in the middle of a line, which is kept until the rest arrives
+	partial := ""
+	for {
+		portion, err := client.DownloadDBLogFilePortion(input)
+		if err != nil {
+			return fmt.Errorf("%v", err)
+		}
+
+		lines := strings.Split(partial+portion.Data, "\n")
+		partial = lines[len(lines)-1]
+		for _, l := range lines[:len(lines)-1] {
+			if filter.Match(l) {
+				fmt.Println(l)
+			}
+		}
+
+		if len(portion.Marker) > 0 {
+			input.Marker = aws.String(portion.Marker)
+		}
+
+		if portion.Pending {
+			continue
+		}
+
+		if !follow {
+			if len(partial) > 0 && filter.Match(partial) {
+				fmt.Println(partial)
+			}
+			return nil
+		}
+
+		time.Sleep(5 * time.Second)
+	}
+}
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/sfuruya0612/snatch/internal/util"
+	"github.com/urfave/cli/v2"
+)
+
+// certificateWarnDays is the number of days before expiry to flag CA certificates.
+const certificateWarnDays = 90
+
+var rdsMaintenanceCommand = &cli.Command{
+	Name:      "maintenance",
+	Aliases:   []string{"m"},
+	Usage:     "Show pending maintenance, maintenance window, CA certificate expiry and upgrade targets of instances and clusters",
+	ArgsUsage: "[ --pending ] [Name]",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "pending",
+			Usage: "Show only resources with pending actions or expiring certificates",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		return getRdsMaintenance(c.String("profile"), c.String("region"), c.Args().First(), c.Bool("pending"))
+	},
+	Subcommands: []*cli.Command{
+		{
+			Name:      "apply",
+			Usage:     "Apply a pending maintenance action at the next window or immediately (interactive confirmation at execute)",
+			ArgsUsage: "[ --action ] <Action> [ --immediately ] <Name>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "action",
+					Usage: "Set pending action (e.g. system-update, db-upgrade, ca-certificate-rotation)",
+				},
+				&cli.BoolFlag{
+					Name:  "immediately",
+					Usage: "Apply the action immediately instead of the next maintenance window",
+				},
+				&cli.BoolFlag{
+					Name:    "yes",
+					Aliases: []string{"y"},
+					Usage:   "Skip confirmation",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 1 {
+					return fmt.Errorf("db instance or cluster identifier is required")
+				}
+				return applyRdsMaintenance(c.String("profile"), c.String("region"), c.Args().First(), c.String("action"), c.Bool("immediately"), c.Bool("yes"))
+			},
+		},
+	},
+}
+
+func getRdsMaintenance(profile, region, name string, pending bool) error {
+	client := saws.NewRdsClient(profile, region)
+
+	instances, err := client.DescribeDBInstances(&rds.DescribeDBInstancesInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return fmt.Errorf("%v", err)
+	}
+
+	clusters, err := client.DescribeDBClusters(&rds.DescribeDBClustersInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return fmt.Errorf("%v", err)
+	}
+
+	actions, err := client.DescribePendingMaintenanceActions(&rds.DescribePendingMaintenanceActionsInput{})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	// upgrade targets are looked up once per engine version
+	upgrades := map[string][]saws.UpgradeTarget{}
+	lookup := func(engine, version string) error {
+		key := engine + " " + version
+		if _, ok := upgrades[key]; ok {
+			return nil
+		}
+		targets, err := client.DescribeUpgradeTargets(engine, version)
+		if err != nil {
+			return err
+		}
+		upgrades[key] = targets
+		return nil
+	}
+
+	for _, c := range clusters {
+		if err := lookup(c.Engine, c.EngineVersion); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+	}
+	for _, i := range instances {
+		if i.Cluster != "None" {
+			continue
+		}
+		if err := lookup(i.Engine, i.EngineVersion); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+	}
+
+	reports := saws.BuildMaintenanceReports(instances, clusters, actions, upgrades)
+
+	printed := 0
+	for _, r := range reports {
+		if len(name) > 0 && r.Name != name {
+			continue
+		}
+
+		expiring := !r.CertificateExpiry.IsZero() && time.Until(r.CertificateExpiry) < certificateWarnDays*24*time.Hour
+		if pending && len(r.Actions) == 0 && !expiring {
+			continue
+		}
+
+		if err := maintenanceTree(r).Print(os.Stdout); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+		printed++
+	}
+
+	if printed == 0 {
+		fmt.Println("No resources found")
+	}
+
+	return nil
+}
+
+func maintenanceTree(r saws.MaintenanceReport) *util.Tree {
+	root := util.NewTree(r.Kind + " " + r.Name + " (" + r.Engine + " " + r.EngineVersion + ")")
+	root.Add("window: " + r.MaintenanceWindow + " (UTC)")
+
+	if r.CACertificate != "None" {
+		label := "certificate: " + r.CACertificate
+		if !r.CertificateExpiry.IsZero() {
+			days := int(time.Until(r.CertificateExpiry).Hours() / 24)
+			label += " expires " + r.CertificateExpiry.Format("2006-01-02")
+			switch {
+			case days < 0:
+				label += " EXPIRED"
+			case days < certificateWarnDays:
+				label += " (in " + strconv.Itoa(days) + "d)"
+			}
+		}
+		root.Add(label)
+	}
+
+	for _, a := range r.Actions {
+		pa := root.Add("pending: " + a.Action + " - " + a.Description)
+
+		schedule := []string{}
+		if !a.CurrentApplyDate.IsZero() {
+			schedule = append(schedule, "applies "+a.CurrentApplyDate.String())
+		}
+		if !a.AutoAppliedAfter.IsZero() {
+			schedule = append(schedule, "auto-applied after "+a.AutoAppliedAfter.String())
+		}
+		if !a.ForcedApplyDate.IsZero() {
+			schedule = append(schedule, "forced "+a.ForcedApplyDate.String())
+		}
+		if a.OptInStatus != "None" {
+			schedule = append(schedule, "opt-in "+a.OptInStatus)
+		}
+		if len(schedule) > 0 {
+			pa.Add(strings.Join(schedule, ", "))
+		}
+	}
+
+	minor, major := []string{}, []string{}
+	for _, u := range r.Upgrades {
+		if u.IsMajor {
+			major = append(major, u.EngineVersion)
+		} else {
+			minor = append(minor, u.EngineVersion)
+		}
+	}
+	if len(minor) > 0 {
+		root.Add("minor: " + strings.Join(minor, ", "))
+	}
+	if len(major) > 0 {
+		root.Add("major: " + strings.Join(major, ", "))
+	}
+
+	return root
+}
+
+// resolveRdsResource returns ARN of the db instance or db cluster, and whether it is a cluster.
+func resolveRdsResource(client *saws.RDS, name string) (string, bool, error) {
+	instances, err := client.DescribeDBInstances(&rds.DescribeDBInstancesInput{
+		Filters: []types.Filter{
+			{Name: aws.String("db-instance-id"), Values: []string{name}},
+		},
+	})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return "", false, err
+	}
+	if len(instances) > 0 {
+		return instances[0].Arn, false, nil
+	}
+
+	clusters, err := client.DescribeDBClusters(&rds.DescribeDBClustersInput{
+		Filters: []types.Filter{
+			{Name: aws.String("db-cluster-id"), Values: []string{name}},
+		},
+	})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return "", false, err
+	}
+	if len(clusters) > 0 {
+		return clusters[0].Arn, true, nil
+	}
+
+	return "", false, fmt.Errorf("db instance or cluster not found: %s", name)
+}
+
+func applyRdsMaintenance(profile, region, name, action string, immediately, yes bool) error {
+	client := saws.NewRdsClient(profile, region)
+
+	arn, _, err := resolveRdsResource(client, name)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	actions, err := client.DescribePendingMaintenanceActions(&rds.DescribePendingMaintenanceActionsInput{
+		ResourceIdentifier: aws.String(arn),
+	})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	list := []string{}
+	for _, a := range actions {
+		list = append(list, a.Action)
+	}
+
+	switch {
+	case len(list) == 0:
+		return fmt.Errorf("no pending maintenance actions: %s", name)
+	case len(action) > 0:
+		found := false
+		for _, a := range list {
+			found = found || a == action
+		}
+		if !found {
+			return fmt.Errorf("%s is not pending on %s (pending: %s)", action, name, strings.Join(list, ","))
+		}
+	case len(list) == 1:
+		action = list[0]
+	default:
+		if action, err = util.Prompt(list, "Select action"); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+	}
+
+	optIn, when := "next-maintenance", "at the next maintenance window"
+	if immediately {
+		optIn, when = "immediate", "immediately"
+	}
+
+	if !yes && !util.Confirm(fmt.Sprintf("apply %s to %s %s", action, name, when)) {
+		return nil
+	}
+
+	if err := client.ApplyPendingMaintenanceAction(arn, action, optIn); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Applying %s to %s %s\n", action, name, when)
+
+	return nil
+}
@@ -0,0 +1,351 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/sfuruya0612/snatch/internal/util"
+	"github.com/urfave/cli/v2"
+)
+
+var rdsYesFlag = &cli.BoolFlag{
+	Name:    "yes",
+	Aliases: []string{"y"},
+	Usage:   "Skip confirmation",
+}
+
+var rdsNoWaitFlag = &cli.BoolFlag{
+	Name:  "no-wait",
+	Usage: "Do not watch the status and events until the operation completes",
+}
+
+var rdsTimeoutFlag = &cli.DurationFlag{
+	Name:  "timeout",
+	Value: 60 * time.Minute,
+	Usage: "Stop watching the status after the duration",
+}
+
+var rdsRebootCommand = &cli.Command{
+	Name:      "reboot",
+	Usage:     "Reboot a DB instance and watch status and events (interactive confirmation at execute)",
+	ArgsUsage: "[ --force-failover ] <DBInstanceIdentifier>",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "force-failover",
+			Usage: "Reboot with failover to the standby of a Multi-AZ instance",
+		},
+		rdsNoWaitFlag,
+		rdsTimeoutFlag,
+		rdsYesFlag,
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return fmt.Errorf("db instance identifier is required")
+		}
+		return rebootRds(c.String("profile"), c.String("region"), c.Args().First(), c.Bool("force-failover"), !c.Bool("no-wait"), c.Duration("timeout"), c.Bool("yes"))
+	},
+}
+
+var rdsStartCommand = &cli.Command{
+	Name:      "start",
+	Usage:     "Start a stopped DB instance or cluster and watch status and events (interactive confirmation at execute)",
+	ArgsUsage: "<DBInstanceIdentifier | DBClusterIdentifier>",
+	Flags: []cli.Flag{
+		rdsNoWaitFlag,
+		rdsTimeoutFlag,
+		rdsYesFlag,
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return fmt.Errorf("db instance or cluster identifier is required")
+		}
+		return startStopRds(c.String("profile"), c.String("region"), c.Args().First(), true, !c.Bool("no-wait"), c.Duration("timeout"), c.Bool("yes"))
+	},
+}
+
+var rdsStopCommand = &cli.Command{
+	Name:      "stop",
+	Usage:     "Stop a DB instance or cluster for up to 7 days and watch status and events (interactive confirmation at execute)",
+	ArgsUsage: "<DBInstanceIdentifier | DBClusterIdentifier>",
+	Flags: []cli.Flag{
+		rdsNoWaitFlag,
+		rdsTimeoutFlag,
+		rdsYesFlag,
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return fmt.Errorf("db instance or cluster identifier is required")
+		}
+		return startStopRds(c.String("profile"), c.String("region"), c.Args().First(), false, !c.Bool("no-wait"), c.Duration("timeout"), c.Bool("yes"))
+	},
+}
+
+var rdsModifyCommand = &cli.Command{
+	Name:      "modify",
+	Usage:     "Change the instance class of a DB instance and watch status and events (interactive confirmation at execute)",
+	ArgsUsage: "--class <DBInstanceClass> [ --apply-immediately ] <DBInstanceIdentifier>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "class",
+			Required: true,
+			Usage:    "Set DB instance class (e.g. db.r7g.large)",
+		},
+		&cli.BoolFlag{
+			Name:  "apply-immediately",
+			Usage: "Apply the change immediately instead of the next maintenance window",
+		},
+		rdsNoWaitFlag,
+		rdsTimeoutFlag,
+		rdsYesFlag,
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return fmt.Errorf("db instance identifier is required")
+		}
+		return modifyRdsClass(c.String("profile"), c.String("region"), c.Args().First(), c.String("class"), c.Bool("apply-immediately"), !c.Bool("no-wait"), c.Duration("timeout"), c.Bool("yes"))
+	},
+}
+
+func rebootRds(profile, region, name string, forceFailover, wait bool, timeout time.Duration, yes bool) error {
+	client := saws.NewRdsClient(profile, region)
+
+	label := "reboot " + name
+	if forceFailover {
+		label += " with failover"
+	}
+
+	if !yes && !util.Confirm(label) {
+		return nil
+	}
+
+	since := time.Now()
+	if err := client.RebootDBInstance(name, forceFailover); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Rebooting %s\n", name)
+
+	if !wait {
+		return nil
+	}
+
+	return watchRdsStatus(client, []rdsResource{{name, false}}, "available", since, timeout)
+}
+
+func failoverRdsCluster(profile, region, name, target string, wait bool, timeout time.Duration, yes bool) error {
+	client := saws.NewRdsClient(profile, region)
+
+	clusters, err := client.DescribeDBClusters(&rds.DescribeDBClustersInput{
+		DBClusterIdentifier: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	// watch the cluster, the current writer and the reader to promote
+	resources, reader := []rdsResource{{name, true}}, false
+	for _, m := range clusters[0].Members {
+		if m.IsWriter || m.Name == target {
+			resources = append(resources, rdsResource{m.Name, false})
+		}
+		reader = reader || (!m.IsWriter && m.Name == target)
+	}
+
+	if len(target) > 0 && !reader {
+		return fmt.Errorf("%s is not a reader of the cluster %s", target, name)
+	}
+
+	label := "failover " + name
+	if len(target) > 0 {
+		label += " to " + target
+	}
+
+	if !yes && !util.Confirm(label) {
+		return nil
+	}
+
+	since := time.Now()
+	if err := client.FailoverDBCluster(name, target); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Failing over %s\n", name)
+
+	if !wait {
+		return nil
+	}
+
+	return watchRdsStatus(client, resources, "available", since, timeout)
+}
+
+func startStopRds(profile, region, name string, start, wait bool, timeout time.Duration, yes bool) error {
+	client := saws.NewRdsClient(profile, region)
+
+	_, cluster, err := resolveRdsResource(client, name)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	kind := "instance"
+	if cluster {
+		kind = "cluster"
+	}
+
+	action, target := "stop", "stopped"
+	if start {
+		action, target = "start", "available"
+	}
+
+	if !yes && !util.Confirm(action+" "+kind+" "+name) {
+		return nil
+	}
+
+	since := time.Now()
+	if start {
+		err = client.StartDB(name, cluster)
+	} else {
+		err = client.StopDB(name, cluster)
+	}
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Requested %s of %s %s\n", action, kind, name)
+
+	if !wait {
+		return nil
+	}
+
+	return watchRdsStatus(client, []rdsResource{{name, cluster}}, target, since, timeout)
+}
+
+func modifyRdsClass(profile, region, name, class string, applyImmediately, wait bool, timeout time.Duration, yes bool) error {
+	client := saws.NewRdsClient(profile, region)
+
+	instances, err := client.DescribeDBInstances(&rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	current := instances[0].DBInstanceClass
+	if current == class {
+		return fmt.Errorf("%s is already %s", name, class)
+	}
+
+	when := "at the next maintenance window"
+	if applyImmediately {
+		when = "immediately"
+	}
+
+	if !yes && !util.Confirm(fmt.Sprintf("modify %s from %s to %s %s", name, current, class, when)) {
+		return nil
+	}
+
+	since := time.Now()
+	if err := client.ModifyDBInstanceClass(name, class, applyImmediately); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Modifying %s to %s %s\n", name, class, when)
+
+	// the change is pending until the maintenance window
+	if !applyImmediately || !wait {
+		return nil
+	}
+
+	return watchRdsStatus(client, []rdsResource{{name, false}}, "available", since, timeout)
+}
+
+// rdsStatus returns the status of the db instance or db cluster.
+func rdsStatus(client *saws.RDS, name string, cluster bool) (string, error) {
+	if cluster {
+		clusters, err := client.DescribeDBClusters(&rds.DescribeDBClustersInput{
+			DBClusterIdentifier: aws.String(name),
+		})
+		if err != nil {
+			return "", err
+		}
+		return clusters[0].Status, nil
+	}
+
+	instances, err := client.DescribeDBInstances(&rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(name),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return instances[0].DBInstanceStatus, nil
+}
+
+// rdsResource is a db instance or db cluster watched by watchRdsStatus.
+type rdsResource struct {
+	name    string
+	cluster bool
+}
+
+// watchRdsStatus polls the status every 15 seconds and prints status changes and RDS events
+// of the resources emitted since the operation, until the status of all resources becomes target.
+// The status may stay target for a while right after the request, so it returns
+// only after another status was seen, or after 2 minutes without any change.
+// It gives up after timeout.
+func watchRdsStatus(client *saws.RDS, resources []rdsResource, target string, since time.Time, timeout time.Duration) error {
+	last, changed := map[string]string{}, false
+	printed := map[string]bool{}
+	for {
+		done := true
+		for _, r := range resources {
+			status, err := rdsStatus(client, r.name, r.cluster)
+			if err != nil {
+				return fmt.Errorf("%v", err)
+			}
+
+			if status != last[r.name] {
+				fmt.Printf("%s\t%s\tstatus\t%s\n", time.Now().Format(time.RFC3339), r.name, status)
+				last[r.name] = status
+			}
+			changed = changed || status != target
+			done = done && status == target
+
+			sourceType := types.SourceTypeDbInstance
+			if r.cluster {
+				sourceType = types.SourceTypeDbCluster
+			}
+
+			events, err := client.DescribeEvents(&rds.DescribeEventsInput{
+				SourceIdentifier: aws.String(r.name),
+				SourceType:       sourceType,
+				StartTime:        aws.Time(since),
+			})
+			if err != nil {
+				return fmt.Errorf("%v", err)
+			}
+
+			for _, e := range events {
+				key := e.SourceId + e.Date.String() + e.Message
+				if printed[key] {
+					continue
+				}
+				printed[key] = true
+				fmt.Printf("%s\t%s\tevent\t%s\n", e.Date.Format(time.RFC3339), r.name, e.Message)
+			}
+		}
+
+		if done && (changed || time.Since(since) > 2*time.Minute) {
+			return nil
+		}
+
+		if time.Since(since) > timeout {
+			return fmt.Errorf("timed out waiting for the status to be %s", target)
+		}
+
+		time.Sleep(15 * time.Second)
+	}
+}
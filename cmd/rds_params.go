@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/sfuruya0612/snatch/internal/util"
+	"github.com/urfave/cli/v2"
+)
+
+var rdsParamsClusterFlag = &cli.BoolFlag{
+	Name:    "cluster",
+	Aliases: []string{"c"},
+	Usage:   "Use DB cluster parameter groups",
+}
+
+var rdsParamsCommand = &cli.Command{
+	Name:      "params",
+	Aliases:   []string{"p"},
+	Usage:     "Get a list of non-default parameters of a parameter group, and instances pending reboot",
+	ArgsUsage: "[ --cluster | -c ] <ParameterGroupName>",
+	Flags: []cli.Flag{
+		rdsParamsClusterFlag,
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return fmt.Errorf("parameter group name is required")
+		}
+		return getRdsParameters(c.String("profile"), c.String("region"), c.Args().First(), c.Bool("cluster"))
+	},
+	Subcommands: []*cli.Command{
+		{
+			Name:      "diff",
+			Usage:     "Show differences between two parameter groups",
+			ArgsUsage: "[ --cluster | -c ] [ --to-profile ] <Profile> [ --to-region ] <Region> <GroupA> <GroupB>",
+			Flags: []cli.Flag{
+				rdsParamsClusterFlag,
+				&cli.StringFlag{
+					Name:  "to-profile",
+					Usage: "Set AWS profile of GroupB (default: --profile)",
+				},
+				&cli.StringFlag{
+					Name:  "to-region",
+					Usage: "Set AWS region of GroupB (default: --region)",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 2 {
+					return fmt.Errorf("two parameter group names are required")
+				}
+				return diffRdsParameters(c.String("profile"), c.String("region"), c.Args().Get(0), c.Args().Get(1), c.String("to-profile"), c.String("to-region"), c.Bool("cluster"))
+			},
+		},
+	},
+}
+
+func describeRdsParameters(client *saws.RDS, group string, cluster bool) ([]saws.DBParameter, error) {
+	if cluster {
+		return client.DescribeDBClusterParameters(&rds.DescribeDBClusterParametersInput{
+			DBClusterParameterGroupName: aws.String(group),
+		})
+	}
+
+	return client.DescribeDBParameters(&rds.DescribeDBParametersInput{
+		DBParameterGroupName: aws.String(group),
+	})
+}
+
+// printPendingReboot prints instances which use the group and are waiting for a reboot to apply the changes.
+func printPendingReboot(client *saws.RDS, group string) error {
+	instances, err := client.DescribeDBInstances(&rds.DescribeDBInstancesInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return err
+	}
+
+	clusters, err := client.DescribeDBClusters(&rds.DescribeDBClustersInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return err
+	}
+
+	members := saws.ParameterGroupMembers([]string{group}, instances, clusters)
+	if len(members) == 0 {
+		fmt.Printf("\nNo instances use %s\n", group)
+		return nil
+	}
+
+	fmt.Println()
+	if err := saws.PrintParameterGroupMembers(os.Stdout, members); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func getRdsParameters(profile, region, group string, cluster bool) error {
+	client := saws.NewRdsClient(profile, region)
+
+	params, err := describeRdsParameters(client, group, cluster)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	list := saws.NonDefaultParameters(params)
+	if len(list) == 0 {
+		fmt.Printf("All parameters of %s are engine defaults\n", group)
+	} else if err := saws.PrintDBParameters(os.Stdout, list); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if err := printPendingReboot(client, group); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func diffRdsParameters(profile, region, groupA, groupB, toProfile, toRegion string, cluster bool) error {
+	if len(toProfile) == 0 {
+		toProfile = profile
+	}
+
+	if len(toRegion) == 0 {
+		toRegion = region
+	}
+
+	clientA := saws.NewRdsClient(profile, region)
+	clientB := saws.NewRdsClient(toProfile, toRegion)
+
+	a, err := describeRdsParameters(clientA, groupA, cluster)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	b, err := describeRdsParameters(clientB, groupB, cluster)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	changes := util.DiffMap(saws.ParameterValues(a), saws.ParameterValues(b))
+	if len(changes) == 0 {
+		fmt.Println("No differences")
+	} else {
+		fmt.Printf("- only in %s, + only in %s, ~ different values\n", groupA, groupB)
+
+		if err := util.PrintChanges(os.Stdout, changes, groupA, groupB); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+	}
+
+	if err := printPendingReboot(clientA, groupA); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if err := printPendingReboot(clientB, groupB); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/urfave/cli/v2"
+)
+
+var rdsS3ExportWatchFlag = &cli.BoolFlag{
+	Name:    "watch",
+	Aliases: []string{"w"},
+	Usage:   "Poll progress until the export task is finished",
+}
+
+var rdsS3ExportCommand = &cli.Command{
+	Name:    "s3export",
+	Aliases: []string{"e"},
+	Usage:   "Get a list of RDS S3 export",
+	Action: func(c *cli.Context) error {
+		return getRdsS3ExportList(c.String("profile"), c.String("region"))
+	},
+	Subcommands: []*cli.Command{
+		{
+			Name:      "start",
+			Usage:     "Start exporting a DB or cluster snapshot to S3 in Parquet format",
+			ArgsUsage: "--snapshot <SnapshotArn | SnapshotId> --bucket <Bucket> --iam-role <RoleArn> --kms-key <KeyId> [ --prefix ] <Prefix> [ --tables ] <Database.Schema.Table> [ --watch ]",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "snapshot",
+					Required: true,
+					Usage:    "Set snapshot ARN or identifier",
+				},
+				&cli.StringFlag{
+					Name:     "bucket",
+					Required: true,
+					Usage:    "Set S3 bucket name",
+				},
+				&cli.StringFlag{
+					Name:  "prefix",
+					Usage: "Set S3 prefix",
+				},
+				&cli.StringFlag{
+					Name:     "iam-role",
+					Required: true,
+					Usage:    "Set ARN of the IAM role which writes to the bucket",
+				},
+				&cli.StringFlag{
+					Name:     "kms-key",
+					Required: true,
+					Usage:    "Set KMS key id or ARN to encrypt the exported data",
+				},
+				&cli.StringSliceFlag{
+					Name:  "tables",
+					Usage: "Set databases, schemas or tables to export (e.g. --tables mydb.public.users,mydb.public.orders)",
+				},
+				&cli.StringFlag{
+					Name:  "name",
+					Usage: "Set export task identifier (default: <SnapshotId>-<yyyymmdd-hhmmss>)",
+				},
+				rdsS3ExportWatchFlag,
+				rdsTimeoutFlag,
+			},
+			Action: func(c *cli.Context) error {
+				input := &rds.StartExportTaskInput{
+					S3BucketName: aws.String(c.String("bucket")),
+					IamRoleArn:   aws.String(c.String("iam-role")),
+					KmsKeyId:     aws.String(c.String("kms-key")),
+					ExportOnly:   c.StringSlice("tables"),
+				}
+				if len(c.String("prefix")) > 0 {
+					input.S3Prefix = aws.String(c.String("prefix"))
+				}
+				return startRdsS3Export(c.String("profile"), c.String("region"), c.String("snapshot"), c.String("name"), input, c.Bool("watch"), c.Duration("timeout"))
+			},
+		},
+		{
+			Name:      "status",
+			Usage:     "Show progress of an export task",
+			ArgsUsage: "[ --watch | -w ] <TaskId>",
+			Flags: []cli.Flag{
+				rdsS3ExportWatchFlag,
+				rdsTimeoutFlag,
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 1 {
+					return fmt.Errorf("export task identifier is required")
+				}
+				return getRdsS3ExportStatus(c.String("profile"), c.String("region"), c.Args().First(), c.Bool("watch"), c.Duration("timeout"))
+			},
+		},
+		{
+			Name:      "cancel",
+			Usage:     "Cancel an export task (exported data is not removed from S3)",
+			ArgsUsage: "<TaskId>",
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 1 {
+					return fmt.Errorf("export task identifier is required")
+				}
+				return cancelRdsS3Export(c.String("profile"), c.String("region"), c.Args().First())
+			},
+		},
+		{
+			Name:      "browse",
+			Usage:     "Get a list of Parquet files which an export task produced",
+			ArgsUsage: "<TaskId>",
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 1 {
+					return fmt.Errorf("export task identifier is required")
+				}
+				return browseRdsS3Export(c.String("profile"), c.String("region"), c.Args().First())
+			},
+		},
+	},
+}
+
+func getRdsS3ExportList(profile, region string) error {
+	c := saws.NewRdsClient(profile, region)
+
+	exports, err := c.DescribeExportTasks(&rds.DescribeExportTasksInput{})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if err := saws.PrintExportTasks(os.Stdout, exports); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func getExportTask(client *saws.RDS, id string) (saws.ExportTasks, error) {
+	tasks, err := client.DescribeExportTasks(&rds.DescribeExportTasksInput{
+		ExportTaskIdentifier: aws.String(id),
+	})
+	if err != nil {
+		return saws.ExportTasks{}, err
+	}
+
+	if len(tasks) == 0 {
+		return saws.ExportTasks{}, fmt.Errorf("export task not found: %s", id)
+	}
+
+	return tasks[0], nil
+}
+
+func startRdsS3Export(profile, region, snapshot, name string, input *rds.StartExportTaskInput, watch bool, timeout time.Duration) error {
+	client := saws.NewRdsClient(profile, region)
+
+	arn := snapshot
+	if !strings.HasPrefix(snapshot, "arn:") {
+		s, err := client.GetSnapshot(snapshot)
+		if err != nil {
+			return fmt.Errorf("%v", err)
+		}
+		arn = s.Arn
+	}
+	input.SourceArn = aws.String(arn)
+
+	// task identifiers allow only letters, digits and hyphens
+	if len(name) == 0 {
+		split := strings.Split(arn, ":")
+		name = split[len(split)-1] + "-" + time.Now().Format("20060102-150405")
+	}
+	input.ExportTaskIdentifier = aws.String(name)
+
+	task, err := client.StartExportTask(input)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Started %s: s3://%s/%s\n", task.ExportTaskIdentifier, task.S3Bucket, task.OutputPrefix())
+
+	if !watch {
+		return nil
+	}
+
+	return watchRdsS3Export(client, task.ExportTaskIdentifier, timeout)
+}
+
+func getRdsS3ExportStatus(profile, region, id string, watch bool, timeout time.Duration) error {
+	client := saws.NewRdsClient(profile, region)
+
+	if watch {
+		return watchRdsS3Export(client, id, timeout)
+	}
+
+	task, err := getExportTask(client, id)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if err := saws.PrintExportTasks(os.Stdout, []saws.ExportTasks{task}); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+// watchRdsS3Export polls the export task every 30 seconds until it is finished or the timeout passes.
+func watchRdsS3Export(client *saws.RDS, id string, timeout time.Duration) error {
+	start, last := time.Now(), ""
+	for {
+		task, err := getExportTask(client, id)
+		if err != nil {
+			return fmt.Errorf("%v", err)
+		}
+
+		line := fmt.Sprintf("%s\t%s\t%d%%\t%dGB extracted", task.ExportTaskIdentifier, task.Status, task.PercentProgress, task.ExtractedDataInGB)
+		if line != last {
+			fmt.Println(line)
+			last = line
+		}
+
+		if task.IsDone() {
+			if task.Status != "COMPLETE" {
+				return fmt.Errorf("export task %s: %s", task.Status, task.FailureCause)
+			}
+			return nil
+		}
+
+		if time.Since(start) > timeout {
+			return fmt.Errorf("timed out waiting for the export task %s", id)
+		}
+
+		time.Sleep(30 * time.Second)
+	}
+}
+
+func cancelRdsS3Export(profile, region, id string) error {
+	client := saws.NewRdsClient(profile, region)
+
+	if err := client.CancelExportTask(&rds.CancelExportTaskInput{
+		ExportTaskIdentifier: aws.String(id),
+	}); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Canceling %s\n", id)
+
+	return nil
+}
+
+func browseRdsS3Export(profile, region, id string) error {
+	task, err := getExportTask(saws.NewRdsClient(profile, region), id)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	objects, err := saws.NewS3Client(profile, region).ListObjects(&s3.ListObjectsV2Input{
+		Bucket: aws.String(task.S3Bucket),
+		Prefix: aws.String(task.OutputPrefix()),
+	})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return fmt.Errorf("%v", err)
+	}
+
+	// export_info and export_tables_info json files are also written under the prefix
+	files := saws.Objects{}
+	for _, o := range objects {
+		if strings.HasSuffix(o.Key, ".parquet") {
+			files = append(files, o)
+		}
+	}
+
+	if len(files) == 0 {
+		fmt.Printf("No Parquet files found in s3://%s/%s\n", task.S3Bucket, task.OutputPrefix())
+		return nil
+	}
+
+	if err := saws.PrintObjects(os.Stdout, files); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
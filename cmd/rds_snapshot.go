@@ -0,0 +1,383 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/sfuruya0612/snatch/internal/util"
+	"github.com/urfave/cli/v2"
+)
+
+var rdsSnapshotWaitFlag = &cli.BoolFlag{
+	Name:    "wait",
+	Aliases: []string{"w"},
+	Usage:   "Wait until the snapshot is available",
+}
+
+var rdsSnapshotYesFlag = &cli.BoolFlag{
+	Name:    "yes",
+	Aliases: []string{"y"},
+	Usage:   "Skip confirmation",
+}
+
+var rdsSnapshotCommand = &cli.Command{
+	Name:    "snapshot",
+	Aliases: []string{"s"},
+	Usage:   "Get a list of manual and automated DB and cluster snapshots",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "source",
+			Usage: "Set DB instance or cluster identifier",
+		},
+		&cli.StringFlag{
+			Name:  "type",
+			Usage: "Set snapshot type (manual | automated)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		return getRdsSnapshotList(c.String("profile"), c.String("region"), c.String("source"), c.String("type"))
+	},
+	Subcommands: []*cli.Command{
+		{
+			Name:      "create",
+			Usage:     "Create a manual snapshot of a DB instance or cluster",
+			ArgsUsage: "[ --cluster ] [ --name ] <SnapshotId> [ --wait ] <Source>",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "cluster",
+					Usage: "Create a snapshot of the DB cluster",
+				},
+				&cli.StringFlag{
+					Name:  "name",
+					Usage: "Set snapshot identifier (default: <Source>-<yyyymmdd-hhmmss>)",
+				},
+				rdsSnapshotWaitFlag,
+				rdsTimeoutFlag,
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 1 {
+					return fmt.Errorf("db instance or cluster identifier is required")
+				}
+				return createRdsSnapshot(c.String("profile"), c.String("region"), c.Args().First(), c.String("name"), c.Bool("cluster"), c.Bool("wait"), c.Duration("timeout"))
+			},
+		},
+		{
+			Name:      "copy",
+			Usage:     "Copy a snapshot to another region, re-encrypting it with the KMS key",
+			ArgsUsage: "--to-region <Region> [ --kms-key ] <KeyId> [ --name ] <SnapshotId> [ --wait ] <SnapshotId>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "to-region",
+					Required: true,
+					Usage:    "Set destination region",
+				},
+				&cli.StringFlag{
+					Name:  "kms-key",
+					Usage: "Set KMS key id or ARN in the destination region (required for encrypted snapshots)",
+				},
+				&cli.StringFlag{
+					Name:  "name",
+					Usage: "Set snapshot identifier of the copy (default: same as the source)",
+				},
+				rdsSnapshotWaitFlag,
+				rdsTimeoutFlag,
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 1 {
+					return fmt.Errorf("snapshot identifier is required")
+				}
+				return copyRdsSnapshot(c.String("profile"), c.String("region"), c.Args().First(), c.String("to-region"), c.String("kms-key"), c.String("name"), c.Bool("wait"), c.Duration("timeout"))
+			},
+		},
+		{
+			Name:      "share",
+			Usage:     "Share a manual snapshot with other accounts (interactive confirmation at execute)",
+			ArgsUsage: "--account <AccountId> [ --remove ] <SnapshotId>",
+			Flags: []cli.Flag{
+				&cli.StringSliceFlag{
+					Name:     "account",
+					Required: true,
+					Usage:    "Set account id (can be specified multiple times, \"all\" makes the snapshot public)",
+				},
+				&cli.BoolFlag{
+					Name:  "remove",
+					Usage: "Stop sharing with the accounts",
+				},
+				rdsSnapshotYesFlag,
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 1 {
+					return fmt.Errorf("snapshot identifier is required")
+				}
+				return shareRdsSnapshot(c.String("profile"), c.String("region"), c.Args().First(), c.StringSlice("account"), c.Bool("remove"), c.Bool("yes"))
+			},
+		},
+		{
+			Name:      "prune",
+			Usage:     "Delete manual snapshots older than the period, keeping the newest ones of each source (interactive confirmation at execute)",
+			ArgsUsage: "--older-than <Duration> [ --keep ] <N> [ --source ] <Source>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "older-than",
+					Required: true,
+					Usage:    "Set age of snapshots to delete (e.g. 720h, 30d, 4w)",
+				},
+				&cli.IntFlag{
+					Name:  "keep",
+					Value: 1,
+					Usage: "Number of the newest snapshots of each source to keep regardless of age",
+				},
+				&cli.StringFlag{
+					Name:  "source",
+					Usage: "Set DB instance or cluster identifier",
+				},
+				rdsSnapshotYesFlag,
+			},
+			Action: func(c *cli.Context) error {
+				return pruneRdsSnapshots(c.String("profile"), c.String("region"), c.String("older-than"), c.Int("keep"), c.String("source"), c.Bool("yes"))
+			},
+		},
+	},
+}
+
+// listRdsSnapshots returns db snapshots and db cluster snapshots.
+// source and snapshotType are optional.
+func listRdsSnapshots(client *saws.RDS, source, snapshotType string) ([]saws.DBSnapshot, error) {
+	input := &rds.DescribeDBSnapshotsInput{}
+	clusterInput := &rds.DescribeDBClusterSnapshotsInput{}
+	if len(snapshotType) > 0 {
+		input.SnapshotType = aws.String(snapshotType)
+		clusterInput.SnapshotType = aws.String(snapshotType)
+	}
+
+	instances, err := client.DescribeDBSnapshots(input)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := client.DescribeDBClusterSnapshots(clusterInput)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []saws.DBSnapshot{}
+	for _, s := range append(instances, clusters...) {
+		if len(source) > 0 && s.Source != source {
+			continue
+		}
+		list = append(list, s)
+	}
+
+	return list, nil
+}
+
+func getRdsSnapshotList(profile, region, source, snapshotType string) error {
+	client := saws.NewRdsClient(profile, region)
+
+	snapshots, err := listRdsSnapshots(client, source, snapshotType)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if len(snapshots) == 0 {
+		fmt.Println("No snapshots found")
+		return nil
+	}
+
+	// only manual snapshots can be shared
+	for i, s := range snapshots {
+		if s.SnapshotType != "manual" {
+			continue
+		}
+		if snapshots[i].SharedAccounts, err = client.SnapshotSharedAccounts(s); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+	}
+
+	if err := saws.PrintDBSnapshots(os.Stdout, snapshots); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func createRdsSnapshot(profile, region, source, name string, cluster, wait bool, timeout time.Duration) error {
+	client := saws.NewRdsClient(profile, region)
+
+	if len(name) == 0 {
+		name = source + "-" + time.Now().Format("20060102-150405")
+	}
+
+	if err := client.CreateSnapshot(source, name, cluster); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Creating snapshot %s of %s\n", name, source)
+
+	if !wait {
+		return nil
+	}
+
+	return waitRdsSnapshot(client, name, timeout)
+}
+
+func copyRdsSnapshot(profile, region, id, toRegion, kmsKey, name string, wait bool, timeout time.Duration) error {
+	client := saws.NewRdsClient(profile, region)
+
+	s, err := client.GetSnapshot(id)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if s.Encrypted && s.Region() != toRegion && len(kmsKey) == 0 {
+		return fmt.Errorf("--kms-key is required to copy an encrypted snapshot to another region")
+	}
+
+	// automated snapshot identifiers have the rds: prefix, which is not allowed for manual ones
+	if len(name) == 0 {
+		name = strings.TrimPrefix(s.SnapshotId, "rds:")
+		if s.Region() == toRegion {
+			name += "-copy"
+		}
+	}
+
+	dst := saws.NewRdsClient(profile, toRegion)
+	if err := dst.CopySnapshot(s, name, kmsKey); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Copying %s to %s in %s\n", s.SnapshotId, name, toRegion)
+
+	if !wait {
+		return nil
+	}
+
+	return waitRdsSnapshot(dst, name, timeout)
+}
+
+// waitRdsSnapshot polls the snapshot until it is available or the timeout passes.
+func waitRdsSnapshot(client *saws.RDS, id string, timeout time.Duration) error {
+	start, last := time.Now(), ""
+	for {
+		s, err := client.GetSnapshot(id)
+		if err != nil {
+			return fmt.Errorf("%v", err)
+		}
+
+		line := s.SnapshotId + "\t" + s.Status + "\t" + strconv.Itoa(int(s.Progress)) + "%"
+		if line != last {
+			fmt.Println(line)
+			last = line
+		}
+
+		switch s.Status {
+		case "available":
+			return nil
+		case "failed", "incompatible-restore", "incompatible-parameters":
+			return fmt.Errorf("snapshot %s: %s", s.SnapshotId, s.Status)
+		}
+
+		if time.Since(start) > timeout {
+			return fmt.Errorf("timed out waiting for the snapshot %s to be available", s.SnapshotId)
+		}
+
+		time.Sleep(15 * time.Second)
+	}
+}
+
+func shareRdsSnapshot(profile, region, id string, accounts []string, remove, yes bool) error {
+	client := saws.NewRdsClient(profile, region)
+
+	s, err := client.GetSnapshot(id)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if s.SnapshotType != "manual" {
+		return fmt.Errorf("only manual snapshots can be shared: %s is %s", s.SnapshotId, s.SnapshotType)
+	}
+
+	action := "share " + s.SnapshotId + " with " + strings.Join(accounts, ",")
+	add, del := accounts, []string{}
+	if remove {
+		action = "stop sharing " + s.SnapshotId + " with " + strings.Join(accounts, ",")
+		add, del = []string{}, accounts
+	}
+
+	if !yes && !util.Confirm(action) {
+		return nil
+	}
+
+	if err := client.ShareSnapshot(s, add, del); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	shared, err := client.SnapshotSharedAccounts(s)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if len(shared) == 0 {
+		fmt.Printf("%s is not shared\n", s.SnapshotId)
+		return nil
+	}
+
+	fmt.Printf("%s is shared with %s\n", s.SnapshotId, strings.Join(shared, ","))
+
+	return nil
+}
+
+func pruneRdsSnapshots(profile, region, olderThan string, keep int, source string, yes bool) error {
+	d, err := util.ParseDuration(olderThan)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if keep < 0 {
+		return fmt.Errorf("--keep must be 0 or more")
+	}
+
+	client := saws.NewRdsClient(profile, region)
+
+	snapshots, err := listRdsSnapshots(client, source, "manual")
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	targets := saws.PruneSnapshots(snapshots, d, keep, time.Now())
+	if len(targets) == 0 {
+		fmt.Println("No snapshots to prune")
+		return nil
+	}
+
+	if err := saws.PrintDBSnapshots(os.Stdout, targets); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if !yes && !util.Confirm(fmt.Sprintf("delete %d snapshots", len(targets))) {
+		return nil
+	}
+
+	failed := 0
+	for _, s := range targets {
+		if err := client.DeleteSnapshot(s); err != nil {
+			fmt.Printf("Failed %s: %v\n", s.SnapshotId, err)
+			failed++
+			continue
+		}
+		fmt.Printf("Deleted %s\n", s.SnapshotId)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d snapshots", failed)
+	}
+
+	return nil
+}
@@ -23,11 +23,266 @@ var Ssm = &cli.Command{
 	Usage: "Use Systems Manager services",
 	Subcommands: []*cli.Command{
 		{
-			Name:    "parameter",
-			Aliases: []string{"p"},
-			Usage:   "Get parameter store",
+			Name:      "parameter",
+			Aliases:   []string{"p"},
+			Usage:     "Get parameter store",
+			ArgsUsage: "[ --path ] <Path> [ --recursive | -R ] [ --decrypt ]",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "path",
+					Usage: "Set parameter path hierarchy to filter (e.g. /app/prod)",
+				},
+				&cli.BoolFlag{
+					Name:    "recursive",
+					Aliases: []string{"R"},
+					Usage:   "Get all parameters within the path hierarchy",
+				},
+				&cli.BoolFlag{
+					Name:  "decrypt",
+					Usage: "Show decrypted SecureString values (masked by default)",
+				},
+			},
 			Action: func(c *cli.Context) error {
-				return getParameter(c.String("profile"), c.String("region"))
+				return getParameter(c.String("profile"), c.String("region"), c.String("path"), c.Bool("recursive"), c.Bool("decrypt"))
+			},
+			Subcommands: []*cli.Command{
+				{
+					Name:      "tree",
+					Usage:     "Show parameter hierarchy as a tree",
+					ArgsUsage: "[ --path ] <Path> [ --decrypt ]",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:  "path",
+							Value: "/",
+							Usage: "Set parameter path hierarchy",
+						},
+						&cli.BoolFlag{
+							Name:  "decrypt",
+							Usage: "Show decrypted SecureString values (masked by default)",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return getParameterTree(c.String("profile"), c.String("region"), c.String("path"), c.Bool("decrypt"))
+					},
+				},
+				{
+					Name:      "history",
+					Usage:     "Show version history of a parameter",
+					ArgsUsage: "[ --decrypt ] <Name>",
+					Flags: []cli.Flag{
+						&cli.BoolFlag{
+							Name:  "decrypt",
+							Usage: "Show decrypted SecureString values (masked by default)",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return getParameterHistory(c.String("profile"), c.String("region"), c.Args().First(), c.Bool("decrypt"))
+					},
+				},
+				{
+					Name:      "diff",
+					Usage:     "Compare two parameter path hierarchies",
+					ArgsUsage: "[ --to-profile ] <Profile> [ --to-region ] <Region> <PathA> <PathB>",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:  "to-profile",
+							Usage: "Set AWS profile of PathB (default: --profile)",
+						},
+						&cli.StringFlag{
+							Name:  "to-region",
+							Usage: "Set AWS region of PathB (default: --region)",
+						},
+						&cli.BoolFlag{
+							Name:  "decrypt",
+							Usage: "Show decrypted SecureString values (masked by default)",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return diffParameters(c.String("profile"), c.String("region"), c.Args().Get(0), c.Args().Get(1), c.String("to-profile"), c.String("to-region"), c.Bool("decrypt"))
+					},
+				},
+				{
+					Name:      "put",
+					Usage:     "Create or update a parameter",
+					ArgsUsage: "[ --name | -n ] <Name> [ --value | -v ] <Value>",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     "name",
+							Aliases:  []string{"n"},
+							Usage:    "Set parameter name",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     "value",
+							Aliases:  []string{"v"},
+							Usage:    "Set parameter value",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:    "type",
+							Aliases: []string{"t"},
+							Value:   "String",
+							Usage:   "Set parameter type (String, StringList or SecureString)",
+						},
+						&cli.StringFlag{
+							Name:  "key-id",
+							Usage: "Set KMS key id to encrypt SecureString (default: alias/aws/ssm)",
+						},
+						&cli.StringFlag{
+							Name:  "tier",
+							Value: "Standard",
+							Usage: "Set parameter tier (Standard, Advanced or Intelligent-Tiering)",
+						},
+						&cli.StringFlag{
+							Name:    "description",
+							Aliases: []string{"d"},
+							Usage:   "Set parameter description",
+						},
+						&cli.BoolFlag{
+							Name:  "overwrite",
+							Usage: "Overwrite the parameter if it already exists",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return putParameter(c.String("profile"), c.String("region"), c.String("name"), c.String("value"), c.String("type"), c.String("key-id"), c.String("tier"), c.String("description"), c.Bool("overwrite"))
+					},
+				},
+				{
+					Name:      "delete",
+					Usage:     "Delete a parameter",
+					ArgsUsage: "[ --name | -n ] <Name>",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     "name",
+							Aliases:  []string{"n"},
+							Usage:    "Set parameter name",
+							Required: true,
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return deleteParameter(c.String("profile"), c.String("region"), c.String("name"))
+					},
+				},
+				{
+					Name:      "copy",
+					Usage:     "Copy a parameter or a path hierarchy to another path, profile or region",
+					ArgsUsage: "[ --src | -s ] <Name|Path> [ --dst | -d ] <Name|Path>",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     "src",
+							Aliases:  []string{"s"},
+							Usage:    "Set source parameter name or path",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     "dst",
+							Aliases:  []string{"d"},
+							Usage:    "Set destination parameter name or path",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:  "to-profile",
+							Usage: "Set destination AWS profile (default: --profile)",
+						},
+						&cli.StringFlag{
+							Name:  "to-region",
+							Usage: "Set destination AWS region (default: --region)",
+						},
+						&cli.StringFlag{
+							Name:  "key-id",
+							Usage: "Set KMS key id to encrypt SecureString at destination (default: alias/aws/ssm)",
+						},
+						&cli.BoolFlag{
+							Name:  "overwrite",
+							Usage: "Overwrite parameters that already exist at destination",
+						},
+						&cli.BoolFlag{
+							Name:  "dry-run",
+							Usage: "Only show the differences",
+						},
+						&cli.BoolFlag{
+							Name:  "decrypt",
+							Usage: "Show decrypted SecureString values in the differences (masked by default)",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return copyParameter(c.String("profile"), c.String("region"), c.String("src"), c.String("dst"), c.String("to-profile"), c.String("to-region"), c.String("key-id"), c.Bool("overwrite"), c.Bool("dry-run"), c.Bool("decrypt"))
+					},
+				},
+				{
+					Name:      "export",
+					Usage:     "Export parameters under a path as env, json or yaml",
+					ArgsUsage: "[ --path ] <Path> [ --format | -f ] <env|json|yaml>",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     "path",
+							Usage:    "Set parameter path (e.g. /app/prod)",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:    "format",
+							Aliases: []string{"f"},
+							Value:   "env",
+							Usage:   "Set output format (env, json or yaml)",
+						},
+						&cli.StringFlag{
+							Name:    "output",
+							Aliases: []string{"o"},
+							Usage:   "Set output file (default: stdout)",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return exportParameters(c.String("profile"), c.String("region"), c.String("path"), c.String("format"), c.String("output"))
+					},
+				},
+				{
+					Name:      "import",
+					Usage:     "Import parameters under a path from env, json or yaml",
+					ArgsUsage: "[ --path ] <Path> [ --file ] <File> [ --format | -f ] <env|json|yaml>",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     "path",
+							Usage:    "Set parameter path (e.g. /app/stg)",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     "file",
+							Usage:    "Set import file",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:    "format",
+							Aliases: []string{"f"},
+							Value:   "env",
+							Usage:   "Set input format (env, json or yaml)",
+						},
+						&cli.StringFlag{
+							Name:    "type",
+							Aliases: []string{"t"},
+							Value:   "String",
+							Usage:   "Set parameter type of imported parameters (String, StringList or SecureString)",
+						},
+						&cli.StringFlag{
+							Name:  "key-id",
+							Usage: "Set KMS key id to encrypt SecureString (default: alias/aws/ssm)",
+						},
+						&cli.BoolFlag{
+							Name:  "overwrite",
+							Usage: "Overwrite parameters that already exist",
+						},
+						&cli.BoolFlag{
+							Name:  "dry-run",
+							Usage: "Only show the differences",
+						},
+						&cli.BoolFlag{
+							Name:  "decrypt",
+							Usage: "Show decrypted SecureString values in the differences (masked by default)",
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return importParameters(c.String("profile"), c.String("region"), c.String("path"), c.String("file"), c.String("format"), c.String("type"), c.String("key-id"), c.Bool("overwrite"), c.Bool("dry-run"), c.Bool("decrypt"))
+					},
+				},
 			},
 		},
 	},
@@ -211,21 +466,397 @@ func sendCommand(profile, region, tag, id, file string, args cli.Args) error {
 	return nil
 }
 
-func getParameter(profile, region string) error {
+func getParameter(profile, region, path string, recursive, decrypt bool) error {
 	client := saws.NewSsmClient(profile, region)
 
-	params, err := client.DescribeParameters(&ssm.DescribeParametersInput{})
+	var param saws.Parameters
+	if len(path) > 0 {
+		params, err := client.GetParametersByPath(&ssm.GetParametersByPathInput{
+			Path:           aws.String(path),
+			Recursive:      aws.Bool(recursive),
+			WithDecryption: aws.Bool(decrypt),
+		})
+		if err != nil {
+			return fmt.Errorf("%v", err)
+		}
+		param = params
+	} else {
+		params, err := client.DescribeParameters(&ssm.DescribeParametersInput{})
+		if err != nil {
+			return fmt.Errorf("%v", err)
+		}
+
+		param, err = client.GetParameter(params, decrypt)
+		if err != nil {
+			return fmt.Errorf("%v", err)
+		}
+	}
+
+	if !decrypt {
+		saws.MaskSecureString(param)
+	}
+
+	if err := saws.PrintParameters(os.Stdout, param); err != nil {
+		return fmt.Errorf("failed to print parameters")
+	}
+
+	return nil
+}
+
+func getParameterTree(profile, region, path string, decrypt bool) error {
+	client := saws.NewSsmClient(profile, region)
+
+	params, err := client.GetParametersByPath(&ssm.GetParametersByPathInput{
+		Path:           aws.String(path),
+		Recursive:      aws.Bool(true),
+		WithDecryption: aws.Bool(decrypt),
+	})
 	if err != nil {
 		return fmt.Errorf("%v", err)
 	}
 
-	param, err := client.GetParameter(params)
+	if len(params) == 0 {
+		return fmt.Errorf("no parameters under %s", path)
+	}
+
+	if !decrypt {
+		saws.MaskSecureString(params)
+	}
+
+	if err := saws.BuildParameterTree(params, path).Print(os.Stdout); err != nil {
+		return fmt.Errorf("failed to print parameters")
+	}
+
+	return nil
+}
+
+func getParameterHistory(profile, region, name string, decrypt bool) error {
+	if len(name) == 0 {
+		return fmt.Errorf("parameter name is required")
+	}
+
+	client := saws.NewSsmClient(profile, region)
+
+	history, err := client.GetParameterHistory(&ssm.GetParameterHistoryInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(decrypt),
+	})
 	if err != nil {
 		return fmt.Errorf("%v", err)
 	}
 
-	if err := saws.PrintParameters(os.Stdout, param); err != nil {
-		return fmt.Errorf("failed to print parameters")
+	if !decrypt {
+		for i := range history {
+			if history[i].Type == string(ssmTypes.ParameterTypeSecureString) {
+				history[i].Value = "********"
+			}
+		}
+	}
+
+	if err := saws.PrintParameterHistory(os.Stdout, history); err != nil {
+		return fmt.Errorf("failed to print parameter history")
+	}
+
+	return nil
+}
+
+func diffParameters(profile, region, pathA, pathB, toProfile, toRegion string, decrypt bool) error {
+	if len(pathA) == 0 || len(pathB) == 0 {
+		return fmt.Errorf("two parameter paths are required")
+	}
+
+	if len(toProfile) == 0 {
+		toProfile = profile
+	}
+
+	if len(toRegion) == 0 {
+		toRegion = region
+	}
+
+	secure := map[string]bool{}
+	values := func(client *saws.SSM, path string) (map[string]string, error) {
+		params, err := client.GetParametersByPath(&ssm.GetParametersByPathInput{
+			Path:           aws.String(path),
+			Recursive:      aws.Bool(true),
+			WithDecryption: aws.Bool(true),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		m := map[string]string{}
+		for _, p := range params {
+			key := saws.ParameterKey(path, p.Name)
+			m[key] = p.Value
+			if p.Type == string(ssmTypes.ParameterTypeSecureString) {
+				secure[key] = true
+			}
+		}
+
+		return m, nil
+	}
+
+	a, err := values(saws.NewSsmClient(profile, region), pathA)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	b, err := values(saws.NewSsmClient(toProfile, toRegion), pathB)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	changes := util.DiffMap(a, b)
+	if len(changes) == 0 {
+		fmt.Println("No differences")
+		return nil
+	}
+
+	if !decrypt {
+		for i := range changes {
+			if secure[changes[i].Key] {
+				changes[i].Old = "********"
+				changes[i].New = "********"
+			}
+		}
+	}
+
+	fmt.Printf("- only in %s, + only in %s, ~ different values\n", pathA, pathB)
+
+	if err := util.PrintChanges(os.Stdout, changes, pathA, pathB); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+func putParameter(profile, region, name, value, ptype, keyId, tier, description string, overwrite bool) error {
+	input := &ssm.PutParameterInput{
+		Name:      aws.String(name),
+		Value:     aws.String(value),
+		Type:      ssmTypes.ParameterType(ptype),
+		Tier:      ssmTypes.ParameterTier(tier),
+		Overwrite: aws.Bool(overwrite),
+	}
+
+	if len(keyId) > 0 {
+		if input.Type != ssmTypes.ParameterTypeSecureString {
+			return fmt.Errorf("key id is only available for SecureString")
+		}
+		input.KeyId = aws.String(keyId)
+	}
+
+	if len(description) > 0 {
+		input.Description = aws.String(description)
+	}
+
+	client := saws.NewSsmClient(profile, region)
+	if err := client.PutParameter(input); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Put %s\n", name)
+
+	return nil
+}
+
+func deleteParameter(profile, region, name string) error {
+	if !util.Confirm(name) {
+		return nil
+	}
+
+	client := saws.NewSsmClient(profile, region)
+	if err := client.DeleteParameter(&ssm.DeleteParameterInput{Name: aws.String(name)}); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Deleted %s\n", name)
+
+	return nil
+}
+
+func copyParameter(profile, region, src, dst, toProfile, toRegion, keyId string, overwrite, dryRun, decrypt bool) error {
+	if len(toProfile) == 0 {
+		toProfile = profile
+	}
+
+	if len(toRegion) == 0 {
+		toRegion = region
+	}
+
+	params, err := getParametersByNameOrPath(saws.NewSsmClient(profile, region), src)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if len(params) == 0 {
+		return fmt.Errorf("parameter not found: %s", src)
+	}
+
+	values := map[string]string{}
+	ptypes := map[string]string{}
+	for _, p := range params {
+		name := dst
+		if p.Name != src {
+			name = saws.ParameterName(dst, saws.ParameterKey(src, p.Name))
+		}
+		values[name] = p.Value
+		ptypes[name] = p.Type
+	}
+
+	client := saws.NewSsmClient(toProfile, toRegion)
+
+	return applyParameters(client, dst, values, ptypes, keyId, overwrite, dryRun, decrypt)
+}
+
+func exportParameters(profile, region, path, format, output string) error {
+	client := saws.NewSsmClient(profile, region)
+
+	params, err := client.GetParametersByPath(&ssm.GetParametersByPathInput{
+		Path:           aws.String(path),
+		Recursive:      aws.Bool(true),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if len(params) == 0 {
+		return fmt.Errorf("no parameters under %s", path)
+	}
+
+	if len(output) == 0 {
+		return saws.ExportParameters(os.Stdout, params, path, format)
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
+	defer f.Close()
+
+	if err := saws.ExportParameters(f, params, path, format); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Exported %d parameters to %s\n", len(params), output)
+
+	return nil
+}
+
+func importParameters(profile, region, path, file, format, ptype, keyId string, overwrite, dryRun, decrypt bool) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("open file %s: %v", file, err)
+	}
+	defer f.Close()
+
+	values, err := saws.ImportParameters(f, path, format)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	ptypes := map[string]string{}
+	for k := range values {
+		ptypes[k] = ptype
+	}
+
+	client := saws.NewSsmClient(profile, region)
+
+	return applyParameters(client, path, values, ptypes, keyId, overwrite, dryRun, decrypt)
+}
+
+// getParametersByNameOrPath returns decrypted parameters under the path,
+// or the single parameter when name is not a path hierarchy.
+func getParametersByNameOrPath(client *saws.SSM, name string) (saws.Parameters, error) {
+	if strings.HasPrefix(name, "/") {
+		params, err := client.GetParametersByPath(&ssm.GetParametersByPathInput{
+			Path:           aws.String(name),
+			Recursive:      aws.Bool(true),
+			WithDecryption: aws.Bool(true),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(params) > 0 {
+			return params, nil
+		}
+	}
+
+	return client.GetParameters(&ssm.GetParametersInput{
+		Names:          []string{name},
+		WithDecryption: aws.Bool(true),
+	})
+}
+
+// applyParameters shows the differences between the current parameters at dst and values,
+// then puts the added (and with overwrite, the modified) parameters after confirmation.
+// Parameters that exist only at dst are never deleted.
+// SecureString values are masked in the differences unless decrypt.
+func applyParameters(client *saws.SSM, dst string, values, ptypes map[string]string, keyId string, overwrite, dryRun, decrypt bool) error {
+	params, err := getParametersByNameOrPath(client, dst)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	// mask when either the current or the new parameter is SecureString
+	secure := map[string]string{}
+	for k, v := range ptypes {
+		secure[k] = v
+	}
+
+	current := map[string]string{}
+	for _, p := range params {
+		if _, ok := values[p.Name]; ok {
+			current[p.Name] = p.Value
+			if p.Type == string(ssmTypes.ParameterTypeSecureString) {
+				secure[p.Name] = p.Type
+			}
+		}
+	}
+
+	changes := util.DiffMap(current, values)
+	if len(changes) == 0 {
+		fmt.Println("No changes")
+		return nil
+	}
+
+	display := changes
+	if !decrypt {
+		display = saws.MaskSecureChanges(changes, secure)
+	}
+
+	if err := util.PrintChanges(os.Stdout, display, "Current", "New"); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if dryRun || !util.Confirm(dst) {
+		return nil
+	}
+
+	for _, c := range changes {
+		if c.Type == util.Modified && !overwrite {
+			fmt.Printf("Skip %s (already exists, use --overwrite)\n", c.Key)
+			continue
+		}
+
+		input := &ssm.PutParameterInput{
+			Name:      aws.String(c.Key),
+			Value:     aws.String(c.New),
+			Type:      ssmTypes.ParameterType(ptypes[c.Key]),
+			Overwrite: aws.Bool(c.Type == util.Modified),
+		}
+
+		if len(keyId) > 0 && input.Type == ssmTypes.ParameterTypeSecureString {
+			input.KeyId = aws.String(keyId)
+		}
+
+		if err := client.PutParameter(input); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+
+		fmt.Printf("Put %s\n", c.Key)
 	}
 
 	return nil
@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/sfuruya0612/snatch/internal/util"
+	"github.com/urfave/cli/v2"
+)
+
+var tagTypeFlag = &cli.StringSliceFlag{
+	Name:  "type",
+	Usage: "Set resource types (e.g. --type ec2:instance,rds:db)",
+}
+
+var tagTargetFlags = []cli.Flag{
+	&cli.StringSliceFlag{
+		Name:  "arn",
+		Usage: "Set ARN of the target resource (can be specified multiple times)",
+	},
+	&cli.StringSliceFlag{
+		Name:  "filter",
+		Usage: "Target resources which have the tag Key=Value (or Key for any value)",
+	},
+	tagTypeFlag,
+	&cli.BoolFlag{
+		Name:    "yes",
+		Aliases: []string{"y"},
+		Usage:   "Skip confirmation",
+	},
+}
+
+var Tag = &cli.Command{
+	Name:  "tag",
+	Usage: "Find, add and remove tags across resource types",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "find",
+			Usage:     "Find resources which have the tags",
+			ArgsUsage: "[ --type ] <ResourceType> <Key=Value | Key> ...",
+			Flags: []cli.Flag{
+				tagTypeFlag,
+			},
+			Action: func(c *cli.Context) error {
+				return findTaggedResources(c.String("profile"), c.String("region"), c.Args().Slice(), c.StringSlice("type"))
+			},
+		},
+		{
+			Name:      "add",
+			Usage:     "Add tags to the resources (interactive confirmation at execute)",
+			ArgsUsage: "[ --arn ] <ARN> [ --filter ] <Key=Value> <Key=Value> ...",
+			Flags:     tagTargetFlags,
+			Action: func(c *cli.Context) error {
+				return addTags(c.String("profile"), c.String("region"), c.Args().Slice(), c.StringSlice("arn"), c.StringSlice("filter"), c.StringSlice("type"), c.Bool("yes"))
+			},
+		},
+		{
+			Name:      "remove",
+			Usage:     "Remove tag keys from the resources (interactive confirmation at execute)",
+			ArgsUsage: "[ --arn ] <ARN> [ --filter ] <Key=Value> <Key> ...",
+			Flags:     tagTargetFlags,
+			Action: func(c *cli.Context) error {
+				return removeTags(c.String("profile"), c.String("region"), c.Args().Slice(), c.StringSlice("arn"), c.StringSlice("filter"), c.StringSlice("type"), c.Bool("yes"))
+			},
+		},
+		{
+			Name:  "compliance",
+			Usage: "Report resources missing required tags, grouped by service (never tagged resources are not reported)",
+			Flags: []cli.Flag{
+				&cli.StringSliceFlag{
+					Name:     "required",
+					Required: true,
+					Usage:    "Set required tag keys (e.g. --required Owner,Env,CostCenter)",
+				},
+				tagTypeFlag,
+			},
+			Action: func(c *cli.Context) error {
+				return getTagCompliance(c.String("profile"), c.String("region"), c.StringSlice("required"), c.StringSlice("type"))
+			},
+		},
+	},
+}
+
+// parseTagFilters returns tag filters from Key=Value or Key strings.
+// Filters with the same key are merged, which matches any of the values.
+func parseTagFilters(args []string) []types.TagFilter {
+	values := map[string][]string{}
+	keys := []string{}
+	for _, a := range args {
+		k, v, ok := strings.Cut(a, "=")
+		if _, exist := values[k]; !exist {
+			keys = append(keys, k)
+			values[k] = []string{}
+		}
+		if ok {
+			values[k] = append(values[k], v)
+		}
+	}
+
+	filters := []types.TagFilter{}
+	for _, k := range keys {
+		filters = append(filters, types.TagFilter{
+			Key:    aws.String(k),
+			Values: values[k],
+		})
+	}
+
+	return filters
+}
+
+func findTaggedResources(profile, region string, args, resourceTypes []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("tag Key=Value is required")
+	}
+
+	client := saws.NewTaggingClient(profile, region)
+
+	resources, err := client.GetResources(&resourcegroupstaggingapi.GetResourcesInput{
+		TagFilters:          parseTagFilters(args),
+		ResourceTypeFilters: resourceTypes,
+	})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if len(resources) == 0 {
+		fmt.Println("No resources found")
+		return nil
+	}
+
+	if err := saws.PrintTaggedResources(os.Stdout, resources); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+// arnLookupBatchSize is the maximum number of ARNs accepted by GetResources.
+const arnLookupBatchSize = 100
+
+// selectTagTargets returns ARNs specified by arns, or resources matching filters.
+// The targets are printed before confirmation.
+func selectTagTargets(client *saws.Tagging, arns, filters, resourceTypes []string) ([]string, error) {
+	if len(arns) > 0 && len(filters) > 0 {
+		return nil, fmt.Errorf("--arn and --filter cannot be specified together")
+	}
+
+	resources := []saws.TaggedResource{}
+	switch {
+	case len(arns) > 0:
+		for i := 0; i < len(arns); i += arnLookupBatchSize {
+			r, err := client.GetResources(&resourcegroupstaggingapi.GetResourcesInput{
+				ResourceARNList: arns[i:min(i+arnLookupBatchSize, len(arns))],
+			})
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, r...)
+		}
+	case len(filters) > 0:
+		r, err := client.GetResources(&resourcegroupstaggingapi.GetResourcesInput{
+			TagFilters:          parseTagFilters(filters),
+			ResourceTypeFilters: resourceTypes,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resources = r
+	default:
+		return nil, fmt.Errorf("--arn or --filter is required")
+	}
+
+	// untagged resources specified by --arn are not returned, so they are targeted as is
+	if len(arns) > 0 {
+		found := map[string]bool{}
+		for _, r := range resources {
+			found[r.ARN] = true
+		}
+		for _, a := range arns {
+			if !found[a] {
+				resources = append(resources, saws.TaggedResource{ARN: a, ResourceType: "None"})
+			}
+		}
+	}
+
+	if len(resources) == 0 {
+		return nil, saws.ErrNoResources
+	}
+
+	if err := saws.PrintTaggedResources(os.Stdout, resources); err != nil {
+		return nil, err
+	}
+
+	targets := []string{}
+	for _, r := range resources {
+		targets = append(targets, r.ARN)
+	}
+
+	return targets, nil
+}
+
+func printTagFailures(failed map[string]string) error {
+	if len(failed) == 0 {
+		return nil
+	}
+
+	arns := []string{}
+	for a := range failed {
+		arns = append(arns, a)
+	}
+	sort.Strings(arns)
+
+	for _, a := range arns {
+		fmt.Printf("Failed %s: %s\n", a, failed[a])
+	}
+
+	return fmt.Errorf("failed to update tags of %d resources", len(failed))
+}
+
+func addTags(profile, region string, args, arns, filters, resourceTypes []string, yes bool) error {
+	tags := map[string]string{}
+	for _, a := range args {
+		k, v, ok := strings.Cut(a, "=")
+		if !ok {
+			return fmt.Errorf("tag is different (e.g. Owner=team-a): %s", a)
+		}
+		tags[k] = v
+	}
+
+	if len(tags) == 0 {
+		return fmt.Errorf("tag Key=Value is required")
+	}
+
+	client := saws.NewTaggingClient(profile, region)
+
+	targets, err := selectTagTargets(client, arns, filters, resourceTypes)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if !yes && !util.Confirm(fmt.Sprintf("add %s to %d resources", strings.Join(args, ","), len(targets))) {
+		return nil
+	}
+
+	failed, err := client.TagResources(targets, tags)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Tagged %d resources\n", len(targets)-len(failed))
+
+	return printTagFailures(failed)
+}
+
+func removeTags(profile, region string, keys, arns, filters, resourceTypes []string, yes bool) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("tag key is required")
+	}
+
+	client := saws.NewTaggingClient(profile, region)
+
+	targets, err := selectTagTargets(client, arns, filters, resourceTypes)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if !yes && !util.Confirm(fmt.Sprintf("remove %s from %d resources", strings.Join(keys, ","), len(targets))) {
+		return nil
+	}
+
+	failed, err := client.UntagResources(targets, keys)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	fmt.Printf("Untagged %d resources\n", len(targets)-len(failed))
+
+	return printTagFailures(failed)
+}
+
+func getTagCompliance(profile, region string, required, resourceTypes []string) error {
+	client := saws.NewTaggingClient(profile, region)
+
+	resources, err := client.GetResources(&resourcegroupstaggingapi.GetResourcesInput{
+		ResourceTypeFilters: resourceTypes,
+	})
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	total := map[string]int{}
+	for _, r := range resources {
+		total[r.Service]++
+	}
+
+	list := saws.CheckTagCompliance(resources, required)
+	if len(list) == 0 {
+		fmt.Printf("All %d resources have the required tags\n", len(resources))
+		return nil
+	}
+
+	if err := saws.PrintTagCompliance(os.Stdout, list, total); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,385 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/sfuruya0612/snatch/internal/util"
+	"github.com/urfave/cli/v2"
+)
+
+// maxTraceDepth limits following CNAME records which point to other records.
+const maxTraceDepth = 5
+
+var Trace = &cli.Command{
+	Name:      "trace",
+	Usage:     "Trace a DNS name to load balancer, listeners, rules, target groups and targets with health",
+	ArgsUsage: "[ --format ] <tree|dot|mermaid> <FQDN>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "format",
+			Value: "tree",
+			Usage: "Set output format (tree | dot | mermaid)",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return fmt.Errorf("fqdn is required")
+		}
+		return trace(c.String("profile"), c.String("region"), c.Args().First(), c.String("format"))
+	},
+}
+
+// tracer holds clients and resources fetched once while tracing.
+type tracer struct {
+	ec2   *saws.EC2
+	elb   *saws.ELB
+	elbv2 *saws.ELBV2
+
+	records   saws.Records
+	balancers []saws.Balancer
+	groups    map[string]saws.TargetGroup
+	instances map[string]string
+	enis      map[string]saws.NetworkInterface
+}
+
+func trace(profile, region, fqdn, format string) error {
+	switch format {
+	case "tree", "dot", "mermaid":
+	default:
+		return fmt.Errorf("unsupported format %s", format)
+	}
+
+	t := &tracer{
+		ec2:   saws.NewEc2Client(profile, region),
+		elb:   saws.NewElbClient(profile, region),
+		elbv2: saws.NewElbV2Client(profile, region),
+
+		groups: map[string]saws.TargetGroup{},
+	}
+
+	// records of every hosted zone, so CNAME chains across zones are followed
+	var err error
+	if t.records, err = saws.NewRoute53Client(profile, region).ListHostedZones(&route53.ListHostedZonesInput{}); err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return fmt.Errorf("%v", err)
+	}
+
+	classic, err := t.elb.DescribeLoadBalancers(&elb.DescribeLoadBalancersInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return fmt.Errorf("%v", err)
+	}
+
+	balancers, err := t.elbv2.DescribeLoadBalancersV2(&elbv2.DescribeLoadBalancersInput{})
+	if err != nil && !errors.Is(err, saws.ErrNoResources) {
+		return fmt.Errorf("%v", err)
+	}
+	t.balancers = append(classic, balancers...)
+
+	root := util.NewTree(strings.TrimSuffix(fqdn, "."))
+
+	found, err := t.traceName(root, fqdn, 0)
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	// the name may be a load balancer DNS name itself
+	if !found {
+		if found, err = t.traceValue(root, fqdn, 0); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("no route53 record or load balancer found for %s", fqdn)
+	}
+
+	switch format {
+	case "dot":
+		err = root.Dot(os.Stdout)
+	case "mermaid":
+		err = root.Mermaid(os.Stdout)
+	default:
+		err = root.Print(os.Stdout)
+	}
+	if err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	return nil
+}
+
+// normalizeDNSName returns lower case name without the trailing dot and the dualstack prefix of ELB.
+func normalizeDNSName(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	return strings.TrimPrefix(name, "dualstack.")
+}
+
+// traceName adds A, AAAA and CNAME records of name and follows their values.
+func (t *tracer) traceName(node *util.Tree, name string, depth int) (bool, error) {
+	found := false
+	for _, r := range t.records {
+		if normalizeDNSName(r.DomainName) != normalizeDNSName(name) {
+			continue
+		}
+		if r.Type != "A" && r.Type != "AAAA" && r.Type != "CNAME" {
+			continue
+		}
+		found = true
+
+		child := node.Add("route53: " + r.Type + " " + r.DomainValue)
+		for _, v := range strings.Split(r.DomainValue, ",") {
+			ok, err := t.traceValue(child, v, depth)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				child.Add("external: " + v)
+			}
+		}
+	}
+
+	return found, nil
+}
+
+// traceValue follows a record value to a load balancer, an IP of EC2 / ENI or another record.
+func (t *tracer) traceValue(node *util.Tree, value string, depth int) (bool, error) {
+	for _, b := range t.balancers {
+		if normalizeDNSName(b.DNSName) == normalizeDNSName(value) {
+			return true, t.traceBalancer(node, b)
+		}
+	}
+
+	if net.ParseIP(value) != nil {
+		label, err := t.describeIp(value)
+		if err != nil {
+			return false, err
+		}
+		node.Add(label)
+		return true, nil
+	}
+
+	if depth >= maxTraceDepth {
+		return false, nil
+	}
+
+	return t.traceName(node, value, depth+1)
+}
+
+func (t *tracer) traceBalancer(node *util.Tree, b saws.Balancer) error {
+	label := "elb: " + b.Name + " (" + b.Type + ", " + b.Scheme + ")"
+	if b.State != "None" {
+		label += " " + b.State
+	}
+	lb := node.Add(label)
+
+	if b.Type == "classic" {
+		targets, err := t.elb.DescribeInstanceHealth(&elb.DescribeInstanceHealthInput{
+			LoadBalancerName: aws.String(b.Name),
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, target := range targets {
+			l, err := t.targetLabel(target, "instance")
+			if err != nil {
+				return err
+			}
+			lb.Add(l)
+		}
+
+		return nil
+	}
+
+	listeners, err := t.elbv2.DescribeListeners(&elbv2.DescribeListenersInput{
+		LoadBalancerArn: aws.String(b.Arn),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, l := range listeners {
+		ln := lb.Add("listener: " + l.Protocol + ":" + strconv.Itoa(int(l.Port)))
+
+		// network load balancers have no rules
+		if b.Type == "application" {
+			rules, err := t.elbv2.DescribeRules(&elbv2.DescribeRulesInput{
+				ListenerArn: aws.String(l.ListenerArn),
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, r := range rules {
+				if err := t.traceActions(ln.Add("rule "+r.Priority+": "+strings.Join(r.Conditions, " ")), r.Actions); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := t.traceActions(ln.Add("default"), l.DefaultActions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *tracer) traceActions(node *util.Tree, actions []saws.ListenerAction) error {
+	for _, a := range actions {
+		if len(a.TargetGroupArns) == 0 {
+			label := a.Type
+			if a.Detail != "None" {
+				label += " " + a.Detail
+			}
+			node.Add(label)
+			continue
+		}
+
+		for _, arn := range a.TargetGroupArns {
+			if err := t.traceTargetGroup(node, arn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *tracer) traceTargetGroup(node *util.Tree, arn string) error {
+	tg, ok := t.groups[arn]
+	if !ok {
+		groups, err := t.elbv2.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+			TargetGroupArns: []string{arn},
+		})
+		if err != nil {
+			return err
+		}
+		if len(groups) == 0 {
+			return fmt.Errorf("target group not found: %s", arn)
+		}
+		tg = groups[0]
+		t.groups[arn] = tg
+	}
+
+	targets, err := t.elbv2.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: aws.String(arn),
+	})
+	if err != nil {
+		return err
+	}
+
+	healthy := 0
+	for _, target := range targets {
+		if target.State == "healthy" {
+			healthy++
+		}
+	}
+
+	tn := node.Add(fmt.Sprintf("tg: %s (%s:%d, %s) %d/%d healthy", tg.Name, tg.Protocol, tg.Port, tg.TargetType, healthy, len(targets)))
+
+	for _, target := range targets {
+		label, err := t.targetLabel(target, tg.TargetType)
+		if err != nil {
+			return err
+		}
+		tn.Add(label)
+	}
+
+	return nil
+}
+
+func (t *tracer) targetLabel(target saws.Target, targetType string) (string, error) {
+	id := target.Id
+	if target.Port > 0 {
+		id += ":" + strconv.Itoa(int(target.Port))
+	}
+
+	owner := ""
+	switch targetType {
+	case "instance":
+		name, err := t.instanceName(target.Id)
+		if err != nil {
+			return "", err
+		}
+		owner = "ec2 " + name
+	case "ip":
+		label, err := t.describeIp(target.Id)
+		if err != nil {
+			return "", err
+		}
+		owner = label
+	default:
+		owner = targetType
+	}
+
+	health := target.State
+	if target.Reason != "None" {
+		health += " (" + target.Reason + ")"
+	}
+
+	return id + " " + health + " [" + owner + "]", nil
+}
+
+func (t *tracer) instanceName(id string) (string, error) {
+	if t.instances == nil {
+		instances, err := t.ec2.DescribeInstances(&ec2.DescribeInstancesInput{})
+		if err != nil && !errors.Is(err, saws.ErrNoResources) {
+			return "", err
+		}
+
+		t.instances = map[string]string{}
+		for _, i := range instances {
+			t.instances[i.InstanceId] = i.Name
+		}
+	}
+
+	return t.instances[id], nil
+}
+
+// describeIp returns the owner of a private or public IP, such as EC2 instance or ECS task.
+func (t *tracer) describeIp(ip string) (string, error) {
+	if t.enis == nil {
+		enis, err := t.ec2.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{})
+		if err != nil {
+			return "", err
+		}
+
+		t.enis = map[string]saws.NetworkInterface{}
+		for _, n := range enis {
+			for _, p := range append(n.PrivateIps, n.PublicIps...) {
+				t.enis[p] = n
+			}
+		}
+	}
+
+	n, ok := t.enis[ip]
+	if !ok {
+		return "ip " + ip, nil
+	}
+
+	owner := n.OwnerService()
+	switch owner {
+	case "ec2":
+		name, err := t.instanceName(n.InstanceId)
+		if err != nil {
+			return "", err
+		}
+		return "ec2 " + name + " " + n.InstanceId, nil
+	case "ecs":
+		return "ecs task " + n.NetworkInterfaceId, nil
+	}
+
+	return owner + " " + n.NetworkInterfaceId, nil
+}
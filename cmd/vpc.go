@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	saws "github.com/sfuruya0612/snatch/internal/aws"
+	"github.com/urfave/cli/v2"
+)
+
+var Vpc = &cli.Command{
+	Name:      "vpc",
+	Usage:     "Show VPC topology (subnets, route tables, NAT gateways and endpoints)",
+	ArgsUsage: "[ --id | -i ] <VpcId>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "id",
+			Aliases: []string{"i"},
+			Usage:   "Set VPC id",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		return getVpcTopology(c.String("profile"), c.String("region"), c.String("id"))
+	},
+}
+
+func getVpcTopology(profile, region, id string) error {
+	client := saws.NewEc2Client(profile, region)
+
+	vpcInput := &ec2.DescribeVpcsInput{}
+	filters := []types.Filter{}
+	if len(id) > 0 {
+		vpcInput.VpcIds = []string{id}
+		filters = append(filters, types.Filter{
+			Name:   aws.String("vpc-id"),
+			Values: []string{id},
+		})
+	}
+
+	var (
+		t   saws.VpcTopology
+		err error
+	)
+
+	if t.Vpcs, err = client.DescribeVpcs(vpcInput); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if len(t.Vpcs) == 0 {
+		return fmt.Errorf("%v", saws.ErrNoResources)
+	}
+
+	if t.Subnets, err = client.DescribeSubnets(&ec2.DescribeSubnetsInput{Filters: filters}); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if t.RouteTables, err = client.DescribeRouteTables(&ec2.DescribeRouteTablesInput{Filters: filters}); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	// deleted NAT gateways remain visible for about an hour, so exclude them
+	natFilters := append(filters, types.Filter{
+		Name:   aws.String("state"),
+		Values: []string{"pending", "available", "failed"},
+	})
+	if t.NatGateways, err = client.DescribeNatGateways(&ec2.DescribeNatGatewaysInput{Filter: natFilters}); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	if t.VpcEndpoints, err = client.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{Filters: filters}); err != nil {
+		return fmt.Errorf("%v", err)
+	}
+
+	for _, tree := range saws.BuildVpcTree(t) {
+		if err := tree.Print(os.Stdout); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
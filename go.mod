@@ -12,7 +12,10 @@ require (
 require (
 	github.com/aws/aws-sdk-go-v2 v1.25.0
 	github.com/aws/aws-sdk-go-v2/config v1.27.0
+	github.com/aws/aws-sdk-go-v2/feature/rds/auth v1.4.0
+	github.com/aws/aws-sdk-go-v2/service/autoscaling v1.39.0
 	github.com/aws/aws-sdk-go-v2/service/cloudformation v1.44.0
+	github.com/aws/aws-sdk-go-v2/service/cloudwatch v1.34.0
 	github.com/aws/aws-sdk-go-v2/service/ec2 v1.147.0
 	github.com/aws/aws-sdk-go-v2/service/ecs v1.39.1
 	github.com/aws/aws-sdk-go-v2/service/elasticache v1.35.0
@@ -20,12 +23,15 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2 v1.28.0
 	github.com/aws/aws-sdk-go-v2/service/iam v1.29.0
 	github.com/aws/aws-sdk-go-v2/service/rds v1.70.0
+	github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi v1.20.0
 	github.com/aws/aws-sdk-go-v2/service/route53 v1.38.0
 	github.com/aws/aws-sdk-go-v2/service/s3 v1.49.0
 	github.com/aws/aws-sdk-go-v2/service/ssm v1.46.1
+	github.com/aws/smithy-go v1.20.0
 	github.com/charmbracelet/lipgloss v0.9.1
 	github.com/imdario/mergo v0.3.16
 	github.com/urfave/cli/v2 v2.27.1
+	gopkg.in/yaml.v2 v2.4.0
 )
 
 require (
@@ -43,7 +49,6 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/sso v1.19.0 // indirect
 	github.com/aws/aws-sdk-go-v2/service/ssooidc v1.22.0 // indirect
 	github.com/aws/aws-sdk-go-v2/service/sts v1.27.0 // indirect
-	github.com/aws/smithy-go v1.20.0 // indirect
 	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
 	github.com/chzyer/readline v1.5.1 // indirect
 	github.com/cpuguy83/go-md2man/v2 v2.0.3 // indirect
@@ -58,5 +63,4 @@ require (
 	github.com/rivo/uniseg v0.4.7 // indirect
 	github.com/russross/blackfriday/v2 v2.1.0 // indirect
 	github.com/xrash/smetrics v0.0.0-20231213231151-1d8dd44e695e // indirect
-	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
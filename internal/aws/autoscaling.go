@@ -0,0 +1,487 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+)
+
+// AutoScaling structure is autoscaling client.
+type AutoScaling struct {
+	Client *autoscaling.Client
+}
+
+// NewAutoScalingClient returns AutoScaling struct initialized.
+func NewAutoScalingClient(profile, region string) *AutoScaling {
+	return &AutoScaling{
+		Client: autoscaling.NewFromConfig(GetSession(profile, region)),
+	}
+}
+
+// Group structure is auto scaling group information.
+type Group struct {
+	Name           string
+	MinSize        int32
+	MaxSize        int32
+	Desired        int32
+	LaunchTemplate string
+	Version        string
+	Status         string
+	Instances      []GroupInstance
+}
+
+// GroupInstance structure is instance information of auto scaling group.
+// Name, PrivateIpAddress and State are filled from the Instance structure by JoinInstances.
+type GroupInstance struct {
+	InstanceId       string
+	InstanceType     string
+	AvailabilityZone string
+	LifecycleState   string
+	HealthStatus     string
+	Version          string
+	Name             string
+	PrivateIpAddress string
+	State            string
+}
+
+// DescribeAutoScalingGroups returns slice Group structure.
+func (c *AutoScaling) DescribeAutoScalingGroups(input *autoscaling.DescribeAutoScalingGroupsInput) ([]Group, error) {
+	list := []Group{}
+	paginator := autoscaling.NewDescribeAutoScalingGroupsPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe auto scaling groups: %v", err)
+		}
+
+		for _, g := range output.AutoScalingGroups {
+			template, version := launchTemplateSpec(g.LaunchTemplate)
+			if g.MixedInstancesPolicy != nil && g.MixedInstancesPolicy.LaunchTemplate != nil {
+				template, version = launchTemplateSpec(g.MixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification)
+			}
+			if g.LaunchConfigurationName != nil {
+				template, version = "lc:"+*g.LaunchConfigurationName, "None"
+			}
+
+			status := "None"
+			if g.Status != nil {
+				status = *g.Status
+			}
+
+			group := Group{
+				Name:           *g.AutoScalingGroupName,
+				MinSize:        *g.MinSize,
+				MaxSize:        *g.MaxSize,
+				Desired:        *g.DesiredCapacity,
+				LaunchTemplate: template,
+				Version:        version,
+				Status:         status,
+			}
+
+			for _, i := range g.Instances {
+				_, version := launchTemplateSpec(i.LaunchTemplate)
+
+				itype := "None"
+				if i.InstanceType != nil {
+					itype = *i.InstanceType
+				}
+
+				health := "None"
+				if i.HealthStatus != nil {
+					health = *i.HealthStatus
+				}
+
+				group.Instances = append(group.Instances, GroupInstance{
+					InstanceId:       *i.InstanceId,
+					InstanceType:     itype,
+					AvailabilityZone: *i.AvailabilityZone,
+					LifecycleState:   string(i.LifecycleState),
+					HealthStatus:     health,
+					Version:          version,
+					Name:             "None",
+					PrivateIpAddress: "None",
+					State:            "None",
+				})
+			}
+
+			list = append(list, group)
+		}
+	}
+
+	if len(list) == 0 {
+		return nil, ErrNoResources
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list, nil
+}
+
+func launchTemplateSpec(s *types.LaunchTemplateSpecification) (string, string) {
+	if s == nil {
+		return "None", "None"
+	}
+
+	name := "None"
+	switch {
+	case s.LaunchTemplateName != nil:
+		name = *s.LaunchTemplateName
+	case s.LaunchTemplateId != nil:
+		name = *s.LaunchTemplateId
+	}
+
+	version := "None"
+	if s.Version != nil {
+		version = *s.Version
+	}
+
+	return name, version
+}
+
+// JoinInstances fills the instance information of the group from the Instance structure.
+func (g *Group) JoinInstances(instances []Instance) {
+	m := map[string]Instance{}
+	for _, i := range instances {
+		m[i.InstanceId] = i
+	}
+
+	for n, i := range g.Instances {
+		if e, ok := m[i.InstanceId]; ok {
+			g.Instances[n].Name = e.Name
+			g.Instances[n].PrivateIpAddress = e.PrivateIpAddress
+			g.Instances[n].State = e.State
+		}
+	}
+}
+
+// InstanceIds returns instance ids of the group.
+func (g *Group) InstanceIds() []string {
+	ids := []string{}
+	for _, i := range g.Instances {
+		ids = append(ids, i.InstanceId)
+	}
+	return ids
+}
+
+// Activity structure is scaling activity information.
+type Activity struct {
+	StartTime   string
+	StatusCode  string
+	Progress    int32
+	Description string
+	Cause       string
+}
+
+// DescribeScalingActivities returns slice Activity structure, newest first.
+func (c *AutoScaling) DescribeScalingActivities(input *autoscaling.DescribeScalingActivitiesInput) ([]Activity, error) {
+	output, err := c.Client.DescribeScalingActivities(context.TODO(), input)
+	if err != nil {
+		return nil, fmt.Errorf("describe scaling activities: %v", err)
+	}
+
+	list := []Activity{}
+	for _, a := range output.Activities {
+		var progress int32
+		if a.Progress != nil {
+			progress = *a.Progress
+		}
+
+		description := "None"
+		if a.Description != nil {
+			description = *a.Description
+		}
+
+		cause := "None"
+		if a.Cause != nil {
+			cause = *a.Cause
+		}
+
+		list = append(list, Activity{
+			StartTime:   a.StartTime.String(),
+			StatusCode:  string(a.StatusCode),
+			Progress:    progress,
+			Description: description,
+			Cause:       cause,
+		})
+	}
+
+	return list, nil
+}
+
+// SetDesiredCapacity changes the desired capacity of the group.
+func (c *AutoScaling) SetDesiredCapacity(input *autoscaling.SetDesiredCapacityInput) error {
+	if _, err := c.Client.SetDesiredCapacity(context.TODO(), input); err != nil {
+		return fmt.Errorf("set desired capacity: %v", err)
+	}
+
+	return nil
+}
+
+// StartInstanceRefresh starts an instance refresh and returns its id.
+func (c *AutoScaling) StartInstanceRefresh(input *autoscaling.StartInstanceRefreshInput) (string, error) {
+	output, err := c.Client.StartInstanceRefresh(context.TODO(), input)
+	if err != nil {
+		return "", fmt.Errorf("start instance refresh: %v", err)
+	}
+
+	return *output.InstanceRefreshId, nil
+}
+
+// InstanceRefresh structure is instance refresh information.
+type InstanceRefresh struct {
+	InstanceRefreshId  string
+	Status             string
+	PercentageComplete int32
+	InstancesToUpdate  int32
+	StartTime          string
+	EndTime            string
+	StatusReason       string
+}
+
+// DescribeInstanceRefreshes returns slice InstanceRefresh structure, newest first.
+func (c *AutoScaling) DescribeInstanceRefreshes(input *autoscaling.DescribeInstanceRefreshesInput) ([]InstanceRefresh, error) {
+	output, err := c.Client.DescribeInstanceRefreshes(context.TODO(), input)
+	if err != nil {
+		return nil, fmt.Errorf("describe instance refreshes: %v", err)
+	}
+
+	list := []InstanceRefresh{}
+	for _, r := range output.InstanceRefreshes {
+		var percentage, toUpdate int32
+		if r.PercentageComplete != nil {
+			percentage = *r.PercentageComplete
+		}
+		if r.InstancesToUpdate != nil {
+			toUpdate = *r.InstancesToUpdate
+		}
+
+		start := "None"
+		if r.StartTime != nil {
+			start = r.StartTime.String()
+		}
+
+		end := "None"
+		if r.EndTime != nil {
+			end = r.EndTime.String()
+		}
+
+		reason := "None"
+		if r.StatusReason != nil {
+			reason = *r.StatusReason
+		}
+
+		list = append(list, InstanceRefresh{
+			InstanceRefreshId:  *r.InstanceRefreshId,
+			Status:             string(r.Status),
+			PercentageComplete: percentage,
+			InstancesToUpdate:  toUpdate,
+			StartTime:          start,
+			EndTime:            end,
+			StatusReason:       reason,
+		})
+	}
+
+	return list, nil
+}
+
+// IsDone reports whether the instance refresh reached a terminal status.
+func (r *InstanceRefresh) IsDone() bool {
+	switch types.InstanceRefreshStatus(r.Status) {
+	case types.InstanceRefreshStatusSuccessful,
+		types.InstanceRefreshStatusFailed,
+		types.InstanceRefreshStatusCancelled,
+		types.InstanceRefreshStatusRollbackSuccessful,
+		types.InstanceRefreshStatusRollbackFailed:
+		return true
+	}
+	return false
+}
+
+func PrintGroups(wrt io.Writer, resources []Group) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"Name",
+		"Min",
+		"Max",
+		"Desired",
+		"InService",
+		"LaunchTemplate",
+		"Version",
+		"Status",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.GroupTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (g *Group) GroupTabString() string {
+	inService := 0
+	for _, i := range g.Instances {
+		if i.LifecycleState == string(types.LifecycleStateInService) {
+			inService++
+		}
+	}
+
+	fields := []string{
+		g.Name,
+		strconv.Itoa(int(g.MinSize)),
+		strconv.Itoa(int(g.MaxSize)),
+		strconv.Itoa(int(g.Desired)),
+		strconv.Itoa(inService),
+		g.LaunchTemplate,
+		g.Version,
+		g.Status,
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+func PrintGroupInstances(wrt io.Writer, resources []GroupInstance) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"Name",
+		"InstanceID",
+		"InstanceType",
+		"PrivateIP",
+		"State",
+		"Lifecycle",
+		"Health",
+		"AZ",
+		"Version",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.GroupInstanceTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (i *GroupInstance) GroupInstanceTabString() string {
+	fields := []string{
+		i.Name,
+		i.InstanceId,
+		i.InstanceType,
+		i.PrivateIpAddress,
+		i.State,
+		i.LifecycleState,
+		i.HealthStatus,
+		i.AvailabilityZone,
+		i.Version,
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+func PrintActivities(wrt io.Writer, resources []Activity) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"StartTime",
+		"Status",
+		"Progress",
+		"Description",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.ActivityTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (a *Activity) ActivityTabString() string {
+	fields := []string{
+		a.StartTime,
+		a.StatusCode,
+		strconv.Itoa(int(a.Progress)) + "%",
+		a.Description,
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+func PrintInstanceRefreshes(wrt io.Writer, resources []InstanceRefresh) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"InstanceRefreshId",
+		"Status",
+		"Percentage",
+		"InstancesToUpdate",
+		"StartTime",
+		"EndTime",
+		"StatusReason",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.InstanceRefreshTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (r *InstanceRefresh) InstanceRefreshTabString() string {
+	fields := []string{
+		r.InstanceRefreshId,
+		r.Status,
+		strconv.Itoa(int(r.PercentageComplete)) + "%",
+		strconv.Itoa(int(r.InstancesToUpdate)),
+		r.StartTime,
+		r.EndTime,
+		r.StatusReason,
+	}
+
+	return strings.Join(fields, "\t")
+}
@@ -0,0 +1,106 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+)
+
+func TestLaunchTemplateSpec(t *testing.T) {
+	cases := []struct {
+		spec    *types.LaunchTemplateSpecification
+		name    string
+		version string
+	}{
+		{nil, "None", "None"},
+		{&types.LaunchTemplateSpecification{LaunchTemplateName: aws.String("web"), Version: aws.String("$Latest")}, "web", "$Latest"},
+		{&types.LaunchTemplateSpecification{LaunchTemplateId: aws.String("lt-0123"), Version: aws.String("3")}, "lt-0123", "3"},
+		{&types.LaunchTemplateSpecification{LaunchTemplateName: aws.String("web")}, "web", "None"},
+	}
+
+	for _, c := range cases {
+		name, version := launchTemplateSpec(c.spec)
+		if name != c.name || version != c.version {
+			t.Errorf("%v should be %s %s, but got %s %s", c.spec, c.name, c.version, name, version)
+		}
+	}
+}
+
+func TestGroupHealthSummary(t *testing.T) {
+	group := Group{
+		Name:           "web",
+		MinSize:        1,
+		MaxSize:        4,
+		Desired:        3,
+		LaunchTemplate: "web",
+		Version:        "$Latest",
+		Status:         "None",
+		Instances: []GroupInstance{
+			{InstanceId: "i-1", LifecycleState: "InService", HealthStatus: "Healthy", Name: "None", PrivateIpAddress: "None", State: "None"},
+			{InstanceId: "i-2", LifecycleState: "Pending", HealthStatus: "Healthy", Name: "None", PrivateIpAddress: "None", State: "None"},
+			{InstanceId: "i-3", LifecycleState: "InService", HealthStatus: "Unhealthy", Name: "None", PrivateIpAddress: "None", State: "None"},
+		},
+	}
+
+	if got, want := group.GroupTabString(), "web\t1\t4\t3\t2\tweb\t$Latest\tNone"; got != want {
+		t.Errorf("GroupTabString should be %q, but got %q", want, got)
+	}
+
+	if got := group.InstanceIds(); !reflect.DeepEqual(got, []string{"i-1", "i-2", "i-3"}) {
+		t.Errorf("InstanceIds should be i-1, i-2, i-3, but got %v", got)
+	}
+
+	group.JoinInstances([]Instance{
+		{InstanceId: "i-1", Name: "web-1", PrivateIpAddress: "10.0.0.1", State: "running"},
+		{InstanceId: "i-9", Name: "other", PrivateIpAddress: "10.0.0.9", State: "running"},
+	})
+
+	if i := group.Instances[0]; i.Name != "web-1" || i.PrivateIpAddress != "10.0.0.1" || i.State != "running" {
+		t.Errorf("i-1 should be joined, but got %v", i)
+	}
+
+	if i := group.Instances[1]; i.Name != "None" || i.State != "None" {
+		t.Errorf("i-2 should not be joined, but got %v", i)
+	}
+}
+
+func TestInstanceRefreshStatus(t *testing.T) {
+	cases := []struct {
+		status string
+		done   bool
+	}{
+		{"Pending", false},
+		{"InProgress", false},
+		{"Cancelling", false},
+		{"RollbackInProgress", false},
+		{"Successful", true},
+		{"Failed", true},
+		{"Cancelled", true},
+		{"RollbackSuccessful", true},
+		{"RollbackFailed", true},
+	}
+
+	for _, c := range cases {
+		r := InstanceRefresh{Status: c.status}
+		if got := r.IsDone(); got != c.done {
+			t.Errorf("%s IsDone should be %v, but got %v", c.status, c.done, got)
+		}
+	}
+
+	r := InstanceRefresh{
+		InstanceRefreshId:  "ir-1",
+		Status:             "InProgress",
+		PercentageComplete: 40,
+		InstancesToUpdate:  3,
+		StartTime:          "2024-03-01 00:00:00 +0000 UTC",
+		EndTime:            "None",
+		StatusReason:       "Waiting for instances to warm up",
+	}
+
+	want := "ir-1\tInProgress\t40%\t3\t2024-03-01 00:00:00 +0000 UTC\tNone\tWaiting for instances to warm up"
+	if got := r.InstanceRefreshTabString(); got != want {
+		t.Errorf("InstanceRefreshTabString should be %q, but got %q", want, got)
+	}
+}
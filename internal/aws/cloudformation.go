@@ -44,30 +44,42 @@ type Event struct {
 // Events Event struct slice
 type Events []Event
 
+// StackResource cloudformation stack resource struct
+type StackResource struct {
+	StackName          string
+	LogicalResourceId  string
+	PhysicalResourceId string
+	ResourceType       string
+}
+
 // DescribeStacks return Stacks
 // input cloudformation.DescribeStacksInput
 func (c *CloudFormation) DescribeStacks(input *cloudformation.DescribeStacksInput) (Stacks, error) {
-	output, err := c.Client.DescribeStacks(context.TODO(), input)
-	if err != nil {
-		return nil, fmt.Errorf("describe stacks: %v", err)
-	}
-
 	list := Stacks{}
-	for _, l := range output.Stacks {
-		update := "None"
-		if l.LastUpdatedTime != nil {
-			update = l.LastUpdatedTime.String()
+	paginator := cloudformation.NewDescribeStacksPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe stacks: %v", err)
 		}
 
-		list = append(list, Stack{
-			Name:       *l.StackName,
-			Status:     string(l.StackStatus),
-			CreateDate: l.CreationTime.String(),
-			UpdateDate: update,
-		})
+		for _, l := range output.Stacks {
+			update := "None"
+			if l.LastUpdatedTime != nil {
+				update = l.LastUpdatedTime.String()
+			}
+
+			list = append(list, Stack{
+				Name:       *l.StackName,
+				Status:     string(l.StackStatus),
+				CreateDate: l.CreationTime.String(),
+				UpdateDate: update,
+			})
+		}
 	}
 	if len(list) == 0 {
-		return nil, fmt.Errorf("no resources")
+		return nil, ErrNoResources
 	}
 
 	return list, nil
@@ -173,3 +185,33 @@ func (i *Event) EventTabString() string {
 
 	return strings.Join(fields, "\t")
 }
+
+// ListStackResources return slice StackResource
+// input cloudformation.ListStackResourcesInput
+func (c *CloudFormation) ListStackResources(input *cloudformation.ListStackResourcesInput) ([]StackResource, error) {
+	list := []StackResource{}
+	paginator := cloudformation.NewListStackResourcesPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("list stack resources: %v", err)
+		}
+
+		for _, r := range output.StackResourceSummaries {
+			physical := "None"
+			if r.PhysicalResourceId != nil {
+				physical = *r.PhysicalResourceId
+			}
+
+			list = append(list, StackResource{
+				StackName:          *input.StackName,
+				LogicalResourceId:  *r.LogicalResourceId,
+				PhysicalResourceId: physical,
+				ResourceType:       *r.ResourceType,
+			})
+		}
+	}
+
+	return list, nil
+}
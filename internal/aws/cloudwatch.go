@@ -0,0 +1,64 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+)
+
+// CloudWatch structure is cloudwatch client.
+type CloudWatch struct {
+	Client *cloudwatch.Client
+}
+
+// NewCloudWatchClient returns CloudWatch struct initialized.
+func NewCloudWatchClient(profile, region string) *CloudWatch {
+	return &CloudWatch{
+		Client: cloudwatch.NewFromConfig(GetSession(profile, region)),
+	}
+}
+
+// Datapoint structure is cloudwatch metric datapoint.
+// Value is the statistic requested by the input.
+type Datapoint struct {
+	Timestamp time.Time
+	Value     float64
+	Unit      string
+}
+
+// GetMetricStatistics returns slice Datapoint structure sorted by timestamp.
+func (c *CloudWatch) GetMetricStatistics(input *cloudwatch.GetMetricStatisticsInput) ([]Datapoint, error) {
+	output, err := c.Client.GetMetricStatistics(context.TODO(), input)
+	if err != nil {
+		return nil, fmt.Errorf("get metric statistics: %v", err)
+	}
+
+	list := []Datapoint{}
+	for _, d := range output.Datapoints {
+		var value *float64
+		for _, v := range []*float64{d.Average, d.Maximum, d.Minimum, d.Sum, d.SampleCount} {
+			if v != nil {
+				value = v
+				break
+			}
+		}
+		if value == nil || d.Timestamp == nil {
+			continue
+		}
+
+		list = append(list, Datapoint{
+			Timestamp: *d.Timestamp,
+			Value:     *value,
+			Unit:      string(d.Unit),
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Timestamp.Before(list[j].Timestamp)
+	})
+
+	return list, nil
+}
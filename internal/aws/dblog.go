@@ -0,0 +1,151 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+
+	"github.com/sfuruya0612/snatch/internal/util"
+)
+
+// DBLogFile structure is rds log file information.
+type DBLogFile struct {
+	LogFileName string
+	Size        int64
+	LastWritten time.Time
+}
+
+// DescribeDBLogFiles returns slice DBLogFile structure sorted by last written time, newest first.
+func (c *RDS) DescribeDBLogFiles(input *rds.DescribeDBLogFilesInput) ([]DBLogFile, error) {
+	list := []DBLogFile{}
+	paginator := rds.NewDescribeDBLogFilesPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe db log files: %v", err)
+		}
+
+		for _, f := range output.DescribeDBLogFiles {
+			list = append(list, DBLogFile{
+				LogFileName: *f.LogFileName,
+				Size:        aws.ToInt64(f.Size),
+				LastWritten: time.UnixMilli(aws.ToInt64(f.LastWritten)),
+			})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].LastWritten.After(list[j].LastWritten)
+	})
+
+	return list, nil
+}
+
+// DBLogPortion structure is a portion of rds log file.
+// Marker is passed to the next request to continue from the end of Data.
+type DBLogPortion struct {
+	Data    string
+	Marker  string
+	Pending bool
+}
+
+// DownloadDBLogFilePortion returns DBLogPortion structure.
+func (c *RDS) DownloadDBLogFilePortion(input *rds.DownloadDBLogFilePortionInput) (DBLogPortion, error) {
+	output, err := c.Client.DownloadDBLogFilePortion(context.TODO(), input)
+	if err != nil {
+		return DBLogPortion{}, fmt.Errorf("download db log file portion: %v", err)
+	}
+
+	return DBLogPortion{
+		Data:    aws.ToString(output.LogFileData),
+		Marker:  aws.ToString(output.Marker),
+		Pending: aws.ToBool(output.AdditionalDataPending),
+	}, nil
+}
+
+func PrintDBLogFiles(wrt io.Writer, resources []DBLogFile) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"LogFileName",
+		"Size",
+		"Age",
+		"LastWritten",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.DBLogFileTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (f *DBLogFile) DBLogFileTabString() string {
+	fields := []string{
+		f.LogFileName,
+		strconv.FormatInt(f.Size, 10),
+		util.FormatAge(f.LastWritten),
+		f.LastWritten.String(),
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// DBLogFilter structure filters lines of rds log files by time and pattern.
+// Lines without a timestamp (e.g. continuation of a query) follow the previous line.
+type DBLogFilter struct {
+	Since   time.Time
+	Pattern *regexp.Regexp
+
+	inRange bool
+}
+
+// Match returns true when the line should be printed.
+func (f *DBLogFilter) Match(line string) bool {
+	if f.Since.IsZero() {
+		f.inRange = true
+	} else if t, ok := parseLogTime(line); ok {
+		f.inRange = !t.Before(f.Since)
+	}
+
+	if !f.inRange {
+		return false
+	}
+
+	return f.Pattern == nil || f.Pattern.MatchString(line)
+}
+
+// parseLogTime returns the leading timestamp of postgresql and mysql log lines, which are UTC.
+func parseLogTime(line string) (time.Time, bool) {
+	s := strings.TrimPrefix(line, "# Time: ")
+	if len(s) < 19 {
+		return time.Time{}, false
+	}
+
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, s[:19]); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,49 @@
+package aws
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestDBLogFilter(t *testing.T) {
+	lines := []string{
+		"2024-03-01 11:59:00 UTC:10.0.0.1(5432):app@db:[100]:LOG:  duration: 1200 ms",
+		"	SELECT * FROM users",
+		"2024-03-01 12:00:00 UTC:10.0.0.1(5432):app@db:[100]:ERROR:  deadlock detected",
+		"	UPDATE orders SET status = 1",
+		"2024-03-01T12:01:00.123456Z 0 [Warning] [MY-010055] IP address could not be resolved",
+		"# Time: 2024-03-01T12:02:00.000000Z",
+		"# Query_time: 3.000000  Lock_time: 0.000000",
+	}
+
+	cases := []struct {
+		filter DBLogFilter
+		want   []int
+	}{
+		{DBLogFilter{}, []int{0, 1, 2, 3, 4, 5, 6}},
+		{DBLogFilter{Since: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}, []int{2, 3, 4, 5, 6}},
+		{DBLogFilter{Pattern: regexp.MustCompile("ERROR|Warning")}, []int{2, 4}},
+		{DBLogFilter{Since: time.Date(2024, 3, 1, 12, 2, 0, 0, time.UTC), Pattern: regexp.MustCompile("Query_time")}, []int{6}},
+	}
+
+	for _, c := range cases {
+		got := []int{}
+		for i, l := range lines {
+			if c.filter.Match(l) {
+				got = append(got, i)
+			}
+		}
+
+		if len(got) != len(c.want) {
+			t.Errorf("should be %v, but got %v", c.want, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("should be %v, but got %v", c.want, got)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+)
+
+// PendingAction structure is rds pending maintenance action information.
+// ResourceArn is the ARN of the db instance or db cluster.
+type PendingAction struct {
+	ResourceArn      string
+	Action           string
+	Description      string
+	AutoAppliedAfter time.Time
+	ForcedApplyDate  time.Time
+	CurrentApplyDate time.Time
+	OptInStatus      string
+}
+
+// DescribePendingMaintenanceActions returns slice PendingAction structure.
+func (c *RDS) DescribePendingMaintenanceActions(input *rds.DescribePendingMaintenanceActionsInput) ([]PendingAction, error) {
+	list := []PendingAction{}
+	paginator := rds.NewDescribePendingMaintenanceActionsPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe pending maintenance actions: %v", err)
+		}
+
+		for _, r := range output.PendingMaintenanceActions {
+			for _, a := range r.PendingMaintenanceActionDetails {
+				description := "None"
+				if a.Description != nil {
+					description = *a.Description
+				}
+
+				optIn := "None"
+				if a.OptInStatus != nil {
+					optIn = *a.OptInStatus
+				}
+
+				list = append(list, PendingAction{
+					ResourceArn:      *r.ResourceIdentifier,
+					Action:           *a.Action,
+					Description:      description,
+					AutoAppliedAfter: aws.ToTime(a.AutoAppliedAfterDate),
+					ForcedApplyDate:  aws.ToTime(a.ForcedApplyDate),
+					CurrentApplyDate: aws.ToTime(a.CurrentApplyDate),
+					OptInStatus:      optIn,
+				})
+			}
+		}
+	}
+
+	return list, nil
+}
+
+// ApplyPendingMaintenanceAction applies the action of the resource.
+// optIn is immediate, next-maintenance or undo-opt-in.
+func (c *RDS) ApplyPendingMaintenanceAction(arn, action, optIn string) error {
+	if _, err := c.Client.ApplyPendingMaintenanceAction(context.TODO(), &rds.ApplyPendingMaintenanceActionInput{
+		ResourceIdentifier: aws.String(arn),
+		ApplyAction:        aws.String(action),
+		OptInType:          aws.String(optIn),
+	}); err != nil {
+		return fmt.Errorf("apply pending maintenance action: %v", err)
+	}
+
+	return nil
+}
+
+// UpgradeTarget structure is a valid engine version to upgrade to.
+type UpgradeTarget struct {
+	EngineVersion string
+	IsMajor       bool
+	AutoUpgrade   bool
+}
+
+// DescribeUpgradeTargets returns valid upgrade targets of the engine version, sorted by version.
+func (c *RDS) DescribeUpgradeTargets(engine, version string) ([]UpgradeTarget, error) {
+	list := []UpgradeTarget{}
+	paginator := rds.NewDescribeDBEngineVersionsPaginator(c.Client, &rds.DescribeDBEngineVersionsInput{
+		Engine:        aws.String(engine),
+		EngineVersion: aws.String(version),
+	})
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe db engine versions: %v", err)
+		}
+
+		for _, v := range output.DBEngineVersions {
+			for _, t := range v.ValidUpgradeTarget {
+				list = append(list, UpgradeTarget{
+					EngineVersion: *t.EngineVersion,
+					IsMajor:       aws.ToBool(t.IsMajorVersionUpgrade),
+					AutoUpgrade:   aws.ToBool(t.AutoUpgrade),
+				})
+			}
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return compareVersion(list[i].EngineVersion, list[j].EngineVersion) < 0
+	})
+
+	return list, nil
+}
+
+// compareVersion compares dot separated versions numerically where possible (e.g. 15.10 > 15.9).
+func compareVersion(a, b string) int {
+	x, y := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < min(len(x), len(y)); i++ {
+		m, errM := strconv.Atoi(x[i])
+		n, errN := strconv.Atoi(y[i])
+		if errM != nil || errN != nil {
+			if c := strings.Compare(x[i], y[i]); c != 0 {
+				return c
+			}
+			continue
+		}
+
+		if m != n {
+			if m < n {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return len(x) - len(y)
+}
+
+// MaintenanceReport structure is maintenance information of a db instance or db cluster.
+// Members of a cluster are reported with the cluster for upgrade targets,
+// and separately for the certificate and instance level actions.
+type MaintenanceReport struct {
+	Kind              string
+	Name              string
+	Arn               string
+	Engine            string
+	EngineVersion     string
+	MaintenanceWindow string
+	CACertificate     string
+	CertificateExpiry time.Time
+	Actions           []PendingAction
+	Upgrades          []UpgradeTarget
+}
+
+// BuildMaintenanceReports returns reports of clusters and instances sorted by name.
+// upgrades is keyed by engine + " " + version.
+func BuildMaintenanceReports(instances []DBInstance, clusters []DBCluster, actions []PendingAction, upgrades map[string][]UpgradeTarget) []MaintenanceReport {
+	byArn := map[string][]PendingAction{}
+	for _, a := range actions {
+		byArn[a.ResourceArn] = append(byArn[a.ResourceArn], a)
+	}
+
+	list := []MaintenanceReport{}
+	for _, c := range clusters {
+		list = append(list, MaintenanceReport{
+			Kind:              "cluster",
+			Name:              c.Name,
+			Arn:               c.Arn,
+			Engine:            c.Engine,
+			EngineVersion:     c.EngineVersion,
+			MaintenanceWindow: c.MaintenanceWindow,
+			CACertificate:     "None",
+			Actions:           byArn[c.Arn],
+			Upgrades:          upgrades[c.Engine+" "+c.EngineVersion],
+		})
+	}
+
+	for _, i := range instances {
+		r := MaintenanceReport{
+			Kind:              "instance",
+			Name:              i.Name,
+			Arn:               i.Arn,
+			Engine:            i.Engine,
+			EngineVersion:     i.EngineVersion,
+			MaintenanceWindow: i.MaintenanceWindow,
+			CACertificate:     i.CACertificate,
+			CertificateExpiry: i.CertificateExpiry,
+			Actions:           byArn[i.Arn],
+		}
+		// the engine version of cluster members is upgraded with the cluster
+		if i.Cluster == "None" {
+			r.Upgrades = upgrades[i.Engine+" "+i.EngineVersion]
+		}
+		list = append(list, r)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Name != list[j].Name {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].Kind < list[j].Kind
+	})
+
+	return list
+}
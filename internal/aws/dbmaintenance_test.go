@@ -0,0 +1,70 @@
+package aws
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompareVersion(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"15.10", "15.9", 1},
+		{"15.4", "16.1", -1},
+		{"8.0.mysql_aurora.3.05.2", "8.0.mysql_aurora.3.10.0", -1},
+		{"13.7", "13.7", 0},
+		{"13", "13.7", -1},
+	}
+
+	for _, c := range cases {
+		got := compareVersion(c.a, c.b)
+		if (got < 0) != (c.want < 0) || (got > 0) != (c.want > 0) {
+			t.Errorf("%s vs %s should be %d, but got %d", c.a, c.b, c.want, got)
+		}
+	}
+}
+
+func TestBuildMaintenanceReports(t *testing.T) {
+	expiry := time.Date(2024, 8, 22, 0, 0, 0, 0, time.UTC)
+
+	instances := []DBInstance{
+		{Name: "db", Arn: "arn:db", Cluster: "None", Engine: "postgres", EngineVersion: "15.4", CACertificate: "rds-ca-2019", CertificateExpiry: expiry},
+		{Name: "cl-1", Arn: "arn:cl-1", Cluster: "cl", Engine: "aurora-postgresql", EngineVersion: "15.4"},
+	}
+	clusters := []DBCluster{
+		{Name: "cl", Arn: "arn:cl", Engine: "aurora-postgresql", EngineVersion: "15.4"},
+	}
+	actions := []PendingAction{
+		{ResourceArn: "arn:db", Action: "system-update"},
+		{ResourceArn: "arn:cl", Action: "db-upgrade"},
+	}
+	upgrades := map[string][]UpgradeTarget{
+		"postgres 15.4":          {{EngineVersion: "15.5"}},
+		"aurora-postgresql 15.4": {{EngineVersion: "15.5"}, {EngineVersion: "16.1", IsMajor: true}},
+	}
+
+	got := BuildMaintenanceReports(instances, clusters, actions, upgrades)
+
+	want := []string{"cl", "cl-1", "db"}
+	if len(got) != len(want) {
+		t.Fatalf("should be %v, but got %v", want, got)
+	}
+	for i, r := range got {
+		if r.Name != want[i] {
+			t.Errorf("should be %s, but got %s", want[i], r.Name)
+		}
+	}
+
+	if len(got[0].Actions) != 1 || len(got[0].Upgrades) != 2 {
+		t.Errorf("cluster should have 1 action and 2 upgrades, but got %v", got[0])
+	}
+
+	if len(got[1].Upgrades) != 0 {
+		t.Errorf("cluster members should not have upgrades, but got %v", got[1].Upgrades)
+	}
+
+	if got[2].CACertificate != "rds-ca-2019" || len(got[2].Actions) != 1 || len(got[2].Upgrades) != 1 {
+		t.Errorf("instance report is different, got %v", got[2])
+	}
+}
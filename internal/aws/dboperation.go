@@ -0,0 +1,136 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+)
+
+// RebootDBInstance reboots the db instance.
+// forceFailover reboots with failover to the standby of a Multi-AZ instance.
+func (c *RDS) RebootDBInstance(id string, forceFailover bool) error {
+	input := &rds.RebootDBInstanceInput{
+		DBInstanceIdentifier: aws.String(id),
+	}
+	if forceFailover {
+		input.ForceFailover = aws.Bool(true)
+	}
+
+	if _, err := c.Client.RebootDBInstance(context.TODO(), input); err != nil {
+		return fmt.Errorf("reboot db instance: %v", err)
+	}
+
+	return nil
+}
+
+// FailoverDBCluster promotes a reader of the db cluster to the writer.
+// When target is empty, RDS selects the reader.
+func (c *RDS) FailoverDBCluster(id, target string) error {
+	input := &rds.FailoverDBClusterInput{
+		DBClusterIdentifier: aws.String(id),
+	}
+	if len(target) > 0 {
+		input.TargetDBInstanceIdentifier = aws.String(target)
+	}
+
+	if _, err := c.Client.FailoverDBCluster(context.TODO(), input); err != nil {
+		return fmt.Errorf("failover db cluster: %v", err)
+	}
+
+	return nil
+}
+
+// StartDB starts the stopped db instance or db cluster.
+func (c *RDS) StartDB(id string, cluster bool) error {
+	if cluster {
+		if _, err := c.Client.StartDBCluster(context.TODO(), &rds.StartDBClusterInput{
+			DBClusterIdentifier: aws.String(id),
+		}); err != nil {
+			return fmt.Errorf("start db cluster: %v", err)
+		}
+		return nil
+	}
+
+	if _, err := c.Client.StartDBInstance(context.TODO(), &rds.StartDBInstanceInput{
+		DBInstanceIdentifier: aws.String(id),
+	}); err != nil {
+		return fmt.Errorf("start db instance: %v", err)
+	}
+
+	return nil
+}
+
+// StopDB stops the db instance or db cluster.
+// RDS starts it again automatically after 7 days.
+func (c *RDS) StopDB(id string, cluster bool) error {
+	if cluster {
+		if _, err := c.Client.StopDBCluster(context.TODO(), &rds.StopDBClusterInput{
+			DBClusterIdentifier: aws.String(id),
+		}); err != nil {
+			return fmt.Errorf("stop db cluster: %v", err)
+		}
+		return nil
+	}
+
+	if _, err := c.Client.StopDBInstance(context.TODO(), &rds.StopDBInstanceInput{
+		DBInstanceIdentifier: aws.String(id),
+	}); err != nil {
+		return fmt.Errorf("stop db instance: %v", err)
+	}
+
+	return nil
+}
+
+// ModifyDBInstanceClass changes the instance class of the db instance.
+// Without applyImmediately, the change is applied in the next maintenance window.
+func (c *RDS) ModifyDBInstanceClass(id, class string, applyImmediately bool) error {
+	if _, err := c.Client.ModifyDBInstance(context.TODO(), &rds.ModifyDBInstanceInput{
+		DBInstanceIdentifier: aws.String(id),
+		DBInstanceClass:      aws.String(class),
+		ApplyImmediately:     aws.Bool(applyImmediately),
+	}); err != nil {
+		return fmt.Errorf("modify db instance: %v", err)
+	}
+
+	return nil
+}
+
+// DBEvent structure is rds event information.
+type DBEvent struct {
+	SourceId   string
+	SourceType string
+	Message    string
+	Date       time.Time
+}
+
+// DescribeEvents returns slice DBEvent structure sorted by date.
+func (c *RDS) DescribeEvents(input *rds.DescribeEventsInput) ([]DBEvent, error) {
+	list := []DBEvent{}
+	paginator := rds.NewDescribeEventsPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe events: %v", err)
+		}
+
+		for _, e := range output.Events {
+			list = append(list, DBEvent{
+				SourceId:   aws.ToString(e.SourceIdentifier),
+				SourceType: string(e.SourceType),
+				Message:    aws.ToString(e.Message),
+				Date:       aws.ToTime(e.Date),
+			})
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Date.Before(list[j].Date)
+	})
+
+	return list, nil
+}
@@ -0,0 +1,246 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// DBParameter structure is rds db or db cluster parameter information.
+// Source is user, system or engine-default.
+type DBParameter struct {
+	Name         string
+	Value        string
+	ApplyType    string
+	Source       string
+	IsModifiable bool
+}
+
+// DescribeDBParameters returns slice DBParameter structure of the db parameter group.
+func (c *RDS) DescribeDBParameters(input *rds.DescribeDBParametersInput) ([]DBParameter, error) {
+	list := []DBParameter{}
+	paginator := rds.NewDescribeDBParametersPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe db parameters: %v", err)
+		}
+
+		list = append(list, newDBParameters(output.Parameters)...)
+	}
+
+	return list, nil
+}
+
+// DescribeDBClusterParameters returns slice DBParameter structure of the db cluster parameter group.
+func (c *RDS) DescribeDBClusterParameters(input *rds.DescribeDBClusterParametersInput) ([]DBParameter, error) {
+	list := []DBParameter{}
+	paginator := rds.NewDescribeDBClusterParametersPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe db cluster parameters: %v", err)
+		}
+
+		list = append(list, newDBParameters(output.Parameters)...)
+	}
+
+	return list, nil
+}
+
+func newDBParameters(params []types.Parameter) []DBParameter {
+	list := []DBParameter{}
+	for _, p := range params {
+		value := "None"
+		if p.ParameterValue != nil {
+			value = *p.ParameterValue
+		}
+
+		list = append(list, DBParameter{
+			Name:         *p.ParameterName,
+			Value:        value,
+			ApplyType:    aws.ToString(p.ApplyType),
+			Source:       aws.ToString(p.Source),
+			IsModifiable: aws.ToBool(p.IsModifiable),
+		})
+	}
+
+	return list
+}
+
+// NonDefaultParameters returns parameters which are not engine defaults, sorted by name.
+func NonDefaultParameters(params []DBParameter) []DBParameter {
+	list := []DBParameter{}
+	for _, p := range params {
+		if p.Source != "engine-default" {
+			list = append(list, p)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list
+}
+
+// ParameterValues returns values of parameters by name.
+// Parameters without value are not included.
+func ParameterValues(params []DBParameter) map[string]string {
+	m := map[string]string{}
+	for _, p := range params {
+		if p.Value != "None" {
+			m[p.Name] = p.Value
+		}
+	}
+
+	return m
+}
+
+func PrintDBParameters(wrt io.Writer, resources []DBParameter) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"Name",
+		"Value",
+		"ApplyType",
+		"Source",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.DBParameterTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (p *DBParameter) DBParameterTabString() string {
+	fields := []string{
+		p.Name,
+		p.Value,
+		p.ApplyType,
+		p.Source,
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// ParameterGroupMember structure is a db instance which uses a parameter group.
+// Status is in-sync, applying or pending-reboot.
+type ParameterGroupMember struct {
+	Group    string
+	Instance string
+	Cluster  string
+	Status   string
+}
+
+// ParameterGroupMembers returns instances which use the groups, sorted by group and instance.
+// Cluster parameter groups are applied to the members of the cluster.
+func ParameterGroupMembers(groups []string, instances []DBInstance, clusters []DBCluster) []ParameterGroupMember {
+	targets := map[string]bool{}
+	for _, g := range groups {
+		targets[g] = true
+	}
+
+	list := []ParameterGroupMember{}
+	for _, i := range instances {
+		if targets[i.ParameterGroup] {
+			list = append(list, ParameterGroupMember{
+				Group:    i.ParameterGroup,
+				Instance: i.Name,
+				Cluster:  "None",
+				Status:   i.ParameterStatus,
+			})
+		}
+	}
+
+	for _, c := range clusters {
+		if !targets[c.ParameterGroup] {
+			continue
+		}
+		for _, m := range c.Members {
+			list = append(list, ParameterGroupMember{
+				Group:    c.ParameterGroup,
+				Instance: m.Name,
+				Cluster:  c.Name,
+				Status:   m.ParameterStatus,
+			})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Group != list[j].Group {
+			return list[i].Group < list[j].Group
+		}
+		return list[i].Instance < list[j].Instance
+	})
+
+	return list
+}
+
+// PendingReboot returns true when the changes of the parameter group are applied at the next reboot.
+func (m *ParameterGroupMember) PendingReboot() bool {
+	return m.Status == "pending-reboot"
+}
+
+func PrintParameterGroupMembers(wrt io.Writer, resources []ParameterGroupMember) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"ParameterGroup",
+		"Instance",
+		"Cluster",
+		"Status",
+		"",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.ParameterGroupMemberTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (m *ParameterGroupMember) ParameterGroupMemberTabString() string {
+	flag := ""
+	if m.PendingReboot() {
+		flag = "REBOOT REQUIRED"
+	}
+
+	fields := []string{
+		m.Group,
+		m.Instance,
+		m.Cluster,
+		m.Status,
+		flag,
+	}
+
+	return strings.Join(fields, "\t")
+}
@@ -0,0 +1,53 @@
+package aws
+
+import "testing"
+
+func TestNonDefaultParameters(t *testing.T) {
+	params := []DBParameter{
+		{Name: "work_mem", Value: "8192", Source: "user"},
+		{Name: "shared_buffers", Value: "{DBInstanceClassMemory/32768}", Source: "system"},
+		{Name: "autovacuum", Value: "None", Source: "engine-default"},
+	}
+
+	got := NonDefaultParameters(params)
+	if len(got) != 2 {
+		t.Fatalf("should be 2, but got %d", len(got))
+	}
+	if got[0].Name != "shared_buffers" || got[1].Name != "work_mem" {
+		t.Errorf("should be sorted by name, but got %v", got)
+	}
+
+	values := ParameterValues(params)
+	if _, ok := values["autovacuum"]; ok {
+		t.Errorf("parameters without value should not be included, but got %v", values)
+	}
+}
+
+func TestParameterGroupMembers(t *testing.T) {
+	instances := []DBInstance{
+		{Name: "db-b", ParameterGroup: "pg-app", ParameterStatus: "pending-reboot"},
+		{Name: "db-a", ParameterGroup: "pg-app", ParameterStatus: "in-sync"},
+		{Name: "db-c", ParameterGroup: "default.postgres15", ParameterStatus: "in-sync"},
+	}
+	clusters := []DBCluster{
+		{Name: "cl", ParameterGroup: "cpg-app", Members: []DBClusterMember{
+			{Name: "cl-1", ParameterStatus: "pending-reboot"},
+		}},
+	}
+
+	got := ParameterGroupMembers([]string{"pg-app", "cpg-app"}, instances, clusters)
+
+	want := []string{"cl-1", "db-a", "db-b"}
+	if len(got) != len(want) {
+		t.Fatalf("should be %v, but got %v", want, got)
+	}
+	for i, m := range got {
+		if m.Instance != want[i] {
+			t.Errorf("should be %s, but got %s", want[i], m.Instance)
+		}
+	}
+
+	if !got[0].PendingReboot() || got[1].PendingReboot() {
+		t.Errorf("pending-reboot should be flagged, but got %v", got)
+	}
+}
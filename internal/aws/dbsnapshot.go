@@ -0,0 +1,400 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+
+	"github.com/sfuruya0612/snatch/internal/util"
+)
+
+// DBSnapshot structure is rds db snapshot or db cluster snapshot information.
+// Source is the db instance or db cluster identifier.
+// SharedAccounts is filled by SnapshotSharedAccounts, "all" means public.
+type DBSnapshot struct {
+	SnapshotId     string
+	Arn            string
+	Source         string
+	IsCluster      bool
+	SnapshotType   string
+	Engine         string
+	Status         string
+	Progress       int32
+	Size           int32
+	Encrypted      bool
+	KmsKeyId       string
+	SharedAccounts []string
+	CreateTime     time.Time
+}
+
+// Kind returns "cluster" or "instance".
+func (s *DBSnapshot) Kind() string {
+	if s.IsCluster {
+		return "cluster"
+	}
+	return "instance"
+}
+
+// Region returns the region in the snapshot ARN.
+func (s *DBSnapshot) Region() string {
+	split := strings.Split(s.Arn, ":")
+	if len(split) < 4 {
+		return ""
+	}
+	return split[3]
+}
+
+// DescribeDBSnapshots returns slice DBSnapshot structure of db instances.
+func (c *RDS) DescribeDBSnapshots(input *rds.DescribeDBSnapshotsInput) ([]DBSnapshot, error) {
+	list := []DBSnapshot{}
+	paginator := rds.NewDescribeDBSnapshotsPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe db snapshots: %v", err)
+		}
+
+		for _, s := range output.DBSnapshots {
+			list = append(list, DBSnapshot{
+				SnapshotId:   *s.DBSnapshotIdentifier,
+				Arn:          aws.ToString(s.DBSnapshotArn),
+				Source:       aws.ToString(s.DBInstanceIdentifier),
+				SnapshotType: aws.ToString(s.SnapshotType),
+				Engine:       aws.ToString(s.Engine),
+				Status:       aws.ToString(s.Status),
+				Progress:     aws.ToInt32(s.PercentProgress),
+				Size:         aws.ToInt32(s.AllocatedStorage),
+				Encrypted:    aws.ToBool(s.Encrypted),
+				KmsKeyId:     kmsKeyId(s.KmsKeyId),
+				CreateTime:   aws.ToTime(s.SnapshotCreateTime),
+			})
+		}
+	}
+
+	sortDBSnapshots(list)
+
+	return list, nil
+}
+
+// DescribeDBClusterSnapshots returns slice DBSnapshot structure of db clusters.
+func (c *RDS) DescribeDBClusterSnapshots(input *rds.DescribeDBClusterSnapshotsInput) ([]DBSnapshot, error) {
+	list := []DBSnapshot{}
+	paginator := rds.NewDescribeDBClusterSnapshotsPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe db cluster snapshots: %v", err)
+		}
+
+		for _, s := range output.DBClusterSnapshots {
+			list = append(list, DBSnapshot{
+				SnapshotId:   *s.DBClusterSnapshotIdentifier,
+				Arn:          aws.ToString(s.DBClusterSnapshotArn),
+				Source:       aws.ToString(s.DBClusterIdentifier),
+				IsCluster:    true,
+				SnapshotType: aws.ToString(s.SnapshotType),
+				Engine:       aws.ToString(s.Engine),
+				Status:       aws.ToString(s.Status),
+				Progress:     aws.ToInt32(s.PercentProgress),
+				Size:         aws.ToInt32(s.AllocatedStorage),
+				Encrypted:    aws.ToBool(s.StorageEncrypted),
+				KmsKeyId:     kmsKeyId(s.KmsKeyId),
+				CreateTime:   aws.ToTime(s.SnapshotCreateTime),
+			})
+		}
+	}
+
+	sortDBSnapshots(list)
+
+	return list, nil
+}
+
+func kmsKeyId(id *string) string {
+	if id == nil || len(*id) == 0 {
+		return "None"
+	}
+	return *id
+}
+
+// sortDBSnapshots sorts snapshots by create time, newest first.
+func sortDBSnapshots(list []DBSnapshot) {
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreateTime.After(list[j].CreateTime)
+	})
+}
+
+// SnapshotSharedAccounts returns account ids which the manual snapshot is shared with.
+func (c *RDS) SnapshotSharedAccounts(s DBSnapshot) ([]string, error) {
+	var attributes []string
+	if s.IsCluster {
+		output, err := c.Client.DescribeDBClusterSnapshotAttributes(context.TODO(), &rds.DescribeDBClusterSnapshotAttributesInput{
+			DBClusterSnapshotIdentifier: aws.String(s.SnapshotId),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describe db cluster snapshot attributes: %v", err)
+		}
+		for _, a := range output.DBClusterSnapshotAttributesResult.DBClusterSnapshotAttributes {
+			if aws.ToString(a.AttributeName) == "restore" {
+				attributes = a.AttributeValues
+			}
+		}
+	} else {
+		output, err := c.Client.DescribeDBSnapshotAttributes(context.TODO(), &rds.DescribeDBSnapshotAttributesInput{
+			DBSnapshotIdentifier: aws.String(s.SnapshotId),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describe db snapshot attributes: %v", err)
+		}
+		for _, a := range output.DBSnapshotAttributesResult.DBSnapshotAttributes {
+			if aws.ToString(a.AttributeName) == "restore" {
+				attributes = a.AttributeValues
+			}
+		}
+	}
+
+	sort.Strings(attributes)
+
+	return attributes, nil
+}
+
+// CreateSnapshot creates a manual snapshot of the db instance or db cluster.
+func (c *RDS) CreateSnapshot(source, id string, cluster bool) error {
+	if cluster {
+		if _, err := c.Client.CreateDBClusterSnapshot(context.TODO(), &rds.CreateDBClusterSnapshotInput{
+			DBClusterIdentifier:         aws.String(source),
+			DBClusterSnapshotIdentifier: aws.String(id),
+		}); err != nil {
+			return fmt.Errorf("create db cluster snapshot: %v", err)
+		}
+		return nil
+	}
+
+	if _, err := c.Client.CreateDBSnapshot(context.TODO(), &rds.CreateDBSnapshotInput{
+		DBInstanceIdentifier: aws.String(source),
+		DBSnapshotIdentifier: aws.String(id),
+	}); err != nil {
+		return fmt.Errorf("create db snapshot: %v", err)
+	}
+
+	return nil
+}
+
+// CopySnapshot copies the snapshot to the region of the client with tags.
+// kmsKeyId re-encrypts the copy, which is required for encrypted snapshots across regions.
+func (c *RDS) CopySnapshot(s DBSnapshot, id, kmsKeyId string) error {
+	var kms, region *string
+	if len(kmsKeyId) > 0 {
+		kms = aws.String(kmsKeyId)
+	}
+	// the presigned url for the source region is generated by the sdk
+	if c.Client.Options().Region != s.Region() {
+		region = aws.String(s.Region())
+	}
+
+	if s.IsCluster {
+		if _, err := c.Client.CopyDBClusterSnapshot(context.TODO(), &rds.CopyDBClusterSnapshotInput{
+			SourceDBClusterSnapshotIdentifier: aws.String(s.Arn),
+			TargetDBClusterSnapshotIdentifier: aws.String(id),
+			KmsKeyId:                          kms,
+			SourceRegion:                      region,
+			CopyTags:                          aws.Bool(true),
+		}); err != nil {
+			return fmt.Errorf("copy db cluster snapshot: %v", err)
+		}
+		return nil
+	}
+
+	if _, err := c.Client.CopyDBSnapshot(context.TODO(), &rds.CopyDBSnapshotInput{
+		SourceDBSnapshotIdentifier: aws.String(s.Arn),
+		TargetDBSnapshotIdentifier: aws.String(id),
+		KmsKeyId:                   kms,
+		SourceRegion:               region,
+		CopyTags:                   aws.Bool(true),
+	}); err != nil {
+		return fmt.Errorf("copy db snapshot: %v", err)
+	}
+
+	return nil
+}
+
+// ShareSnapshot adds or removes accounts which can restore the manual snapshot.
+func (c *RDS) ShareSnapshot(s DBSnapshot, add, remove []string) error {
+	if s.IsCluster {
+		if _, err := c.Client.ModifyDBClusterSnapshotAttribute(context.TODO(), &rds.ModifyDBClusterSnapshotAttributeInput{
+			DBClusterSnapshotIdentifier: aws.String(s.SnapshotId),
+			AttributeName:               aws.String("restore"),
+			ValuesToAdd:                 add,
+			ValuesToRemove:              remove,
+		}); err != nil {
+			return fmt.Errorf("modify db cluster snapshot attribute: %v", err)
+		}
+		return nil
+	}
+
+	if _, err := c.Client.ModifyDBSnapshotAttribute(context.TODO(), &rds.ModifyDBSnapshotAttributeInput{
+		DBSnapshotIdentifier: aws.String(s.SnapshotId),
+		AttributeName:        aws.String("restore"),
+		ValuesToAdd:          add,
+		ValuesToRemove:       remove,
+	}); err != nil {
+		return fmt.Errorf("modify db snapshot attribute: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteSnapshot deletes the manual snapshot.
+func (c *RDS) DeleteSnapshot(s DBSnapshot) error {
+	if s.IsCluster {
+		if _, err := c.Client.DeleteDBClusterSnapshot(context.TODO(), &rds.DeleteDBClusterSnapshotInput{
+			DBClusterSnapshotIdentifier: aws.String(s.SnapshotId),
+		}); err != nil {
+			return fmt.Errorf("delete db cluster snapshot: %v", err)
+		}
+		return nil
+	}
+
+	if _, err := c.Client.DeleteDBSnapshot(context.TODO(), &rds.DeleteDBSnapshotInput{
+		DBSnapshotIdentifier: aws.String(s.SnapshotId),
+	}); err != nil {
+		return fmt.Errorf("delete db snapshot: %v", err)
+	}
+
+	return nil
+}
+
+// GetSnapshot returns the db snapshot or db cluster snapshot with the identifier.
+func (c *RDS) GetSnapshot(id string) (DBSnapshot, error) {
+	snapshots, err := c.DescribeDBSnapshots(&rds.DescribeDBSnapshotsInput{
+		Filters: []types.Filter{
+			{Name: aws.String("db-snapshot-id"), Values: []string{id}},
+		},
+	})
+	if err != nil {
+		return DBSnapshot{}, err
+	}
+
+	if len(snapshots) == 0 {
+		snapshots, err = c.DescribeDBClusterSnapshots(&rds.DescribeDBClusterSnapshotsInput{
+			Filters: []types.Filter{
+				{Name: aws.String("db-cluster-snapshot-id"), Values: []string{id}},
+			},
+		})
+		if err != nil {
+			return DBSnapshot{}, err
+		}
+	}
+
+	if len(snapshots) == 0 {
+		return DBSnapshot{}, fmt.Errorf("snapshot not found: %s", id)
+	}
+
+	return snapshots[0], nil
+}
+
+// PruneSnapshots returns manual snapshots created before now - olderThan,
+// keeping the newest keep snapshots of each source regardless of age.
+func PruneSnapshots(snapshots []DBSnapshot, olderThan time.Duration, keep int, now time.Time) []DBSnapshot {
+	bySource := map[string][]DBSnapshot{}
+	sources := []string{}
+	for _, s := range snapshots {
+		if s.SnapshotType != "manual" {
+			continue
+		}
+		key := s.Kind() + "/" + s.Source
+		if _, ok := bySource[key]; !ok {
+			sources = append(sources, key)
+		}
+		bySource[key] = append(bySource[key], s)
+	}
+	sort.Strings(sources)
+
+	cutoff := now.Add(-olderThan)
+
+	list := []DBSnapshot{}
+	for _, src := range sources {
+		group := bySource[src]
+		sortDBSnapshots(group)
+
+		for i, s := range group {
+			if i < keep || !s.CreateTime.Before(cutoff) {
+				continue
+			}
+			list = append(list, s)
+		}
+	}
+
+	return list
+}
+
+func PrintDBSnapshots(wrt io.Writer, resources []DBSnapshot) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"SnapshotId",
+		"Kind",
+		"Source",
+		"Type",
+		"Engine",
+		"Status",
+		"Size",
+		"Encrypted",
+		"Shared",
+		"Age",
+		"CreateTime",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.DBSnapshotTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (s *DBSnapshot) DBSnapshotTabString() string {
+	status := s.Status
+	if s.Status == "creating" {
+		status += "(" + strconv.Itoa(int(s.Progress)) + "%)"
+	}
+
+	shared := "None"
+	if len(s.SharedAccounts) > 0 {
+		shared = strings.Join(s.SharedAccounts, ",")
+	}
+
+	fields := []string{
+		s.SnapshotId,
+		s.Kind(),
+		s.Source,
+		s.SnapshotType,
+		s.Engine,
+		status,
+		strconv.Itoa(int(s.Size)) + "GB",
+		strconv.FormatBool(s.Encrypted),
+		shared,
+		util.FormatAge(s.CreateTime),
+		s.CreateTime.String(),
+	}
+
+	return strings.Join(fields, "\t")
+}
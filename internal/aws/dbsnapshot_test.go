@@ -0,0 +1,36 @@
+package aws
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPruneSnapshots(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	snapshots := []DBSnapshot{
+		{SnapshotId: "db-1", Source: "db", SnapshotType: "manual", CreateTime: now.Add(-1 * day)},
+		{SnapshotId: "db-40", Source: "db", SnapshotType: "manual", CreateTime: now.Add(-40 * day)},
+		{SnapshotId: "db-50", Source: "db", SnapshotType: "manual", CreateTime: now.Add(-50 * day)},
+		{SnapshotId: "db-60", Source: "db", SnapshotType: "manual", CreateTime: now.Add(-60 * day)},
+		{SnapshotId: "rds:db-auto", Source: "db", SnapshotType: "automated", CreateTime: now.Add(-90 * day)},
+		{SnapshotId: "cl-90", Source: "db", IsCluster: true, SnapshotType: "manual", CreateTime: now.Add(-90 * day)},
+	}
+
+	got := PruneSnapshots(snapshots, 30*day, 2, now)
+
+	want := []string{"db-50", "db-60"}
+	if len(got) != len(want) {
+		t.Fatalf("should be %v, but got %v", want, got)
+	}
+	for i, s := range got {
+		if s.SnapshotId != want[i] {
+			t.Errorf("should be %s, but got %s", want[i], s.SnapshotId)
+		}
+	}
+
+	if got := PruneSnapshots(snapshots, 30*day, 0, now); len(got) != 4 {
+		t.Errorf("should be 4, but got %d", len(got))
+	}
+}
@@ -2,13 +2,19 @@ package aws
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
 )
 
 // EC2 structure is ec2 client.
@@ -35,69 +41,339 @@ type Instance struct {
 	KeyName          string
 	AvailabilityZone string
 	LaunchTime       string
+	ImageId          string
+	SecurityGroups   []string
 }
 
 // DescribeInstances returns slice Instance structure.
 func (c *EC2) DescribeInstances(input *ec2.DescribeInstancesInput) ([]Instance, error) {
-	ctx := context.TODO()
-	output, err := c.Client.DescribeInstances(ctx, input)
+	list := []Instance{}
+	paginator := ec2.NewDescribeInstancesPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe instances: %v", err)
+		}
+
+		for _, r := range output.Reservations {
+			for _, i := range r.Instances {
+				list = append(list, newInstance(i))
+			}
+		}
+	}
+
+	if len(list) == 0 {
+		return nil, ErrNoResources
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list, nil
+}
+
+// InstanceDetail structure is detailed ec2 instance information.
+type InstanceDetail struct {
+	Instance          Instance
+	Tags              [][2]string
+	ImageId           string
+	ImageName         string
+	SubnetId          string
+	SubnetName        string
+	VpcId             string
+	VpcName           string
+	InstanceProfile   string
+	HttpTokens        string
+	HttpEndpoint      string
+	HttpHopLimit      string
+	SsmPingStatus     string
+	NetworkInterfaces []NetworkInterface
+	Volumes           []Volume
+	SecurityGroups    []SecurityGroup
+}
+
+// DescribeInstanceDetail returns InstanceDetail structure of the instance.
+// SsmPingStatus is not filled because it comes from Systems Manager.
+func (c *EC2) DescribeInstanceDetail(id string) (*InstanceDetail, error) {
+	output, err := c.Client.DescribeInstances(context.TODO(), &ec2.DescribeInstancesInput{
+		InstanceIds: []string{id},
+	})
 	if err != nil {
 		return nil, fmt.Errorf("describe instances: %v", err)
 	}
 
-	if len(output.Reservations) == 0 {
-		return nil, fmt.Errorf("no resources")
+	if len(output.Reservations) == 0 || len(output.Reservations[0].Instances) == 0 {
+		return nil, ErrNoResources
 	}
+	i := output.Reservations[0].Instances[0]
 
-	list := []Instance{}
-	for _, r := range output.Reservations {
-		for _, i := range r.Instances {
-			name := ""
-			for _, t := range i.Tags {
-				if *t.Key == "Name" {
-					name = *t.Value
-				}
-			}
+	d := &InstanceDetail{
+		Instance:        newInstance(i),
+		ImageId:         *i.ImageId,
+		ImageName:       "None",
+		SubnetId:        "None",
+		VpcId:           "None",
+		InstanceProfile: "None",
+		SsmPingStatus:   "None",
+	}
 
-			priip := "None"
-			if i.PrivateIpAddress != nil {
-				priip = *i.PrivateIpAddress
-			}
+	for _, t := range i.Tags {
+		d.Tags = append(d.Tags, [2]string{*t.Key, *t.Value})
+	}
+	sort.Slice(d.Tags, func(x, y int) bool {
+		return d.Tags[x][0] < d.Tags[y][0]
+	})
 
-			pubip := "None"
-			if i.PublicIpAddress != nil {
-				pubip = *i.PublicIpAddress
-			}
+	if i.IamInstanceProfile != nil {
+		spl := strings.Split(*i.IamInstanceProfile.Arn, "/")
+		d.InstanceProfile = spl[len(spl)-1]
+	}
 
-			key := "None"
-			if i.KeyName != nil {
-				key = *i.KeyName
+	if i.MetadataOptions != nil {
+		d.HttpTokens = string(i.MetadataOptions.HttpTokens)
+		d.HttpEndpoint = string(i.MetadataOptions.HttpEndpoint)
+		if i.MetadataOptions.HttpPutResponseHopLimit != nil {
+			d.HttpHopLimit = strconv.Itoa(int(*i.MetadataOptions.HttpPutResponseHopLimit))
+		}
+	}
+
+	for _, n := range i.NetworkInterfaces {
+		eni := NetworkInterface{
+			NetworkInterfaceId: *n.NetworkInterfaceId,
+			SubnetId:           *n.SubnetId,
+		}
+		for _, p := range n.PrivateIpAddresses {
+			eni.PrivateIps = append(eni.PrivateIps, *p.PrivateIpAddress)
+			if p.Association != nil && p.Association.PublicIp != nil {
+				eni.PublicIps = append(eni.PublicIps, *p.Association.PublicIp)
 			}
+		}
+		for _, g := range n.Groups {
+			eni.SecurityGroups = append(eni.SecurityGroups, *g.GroupId)
+		}
+		d.NetworkInterfaces = append(d.NetworkInterfaces, eni)
+	}
 
-			// AvailabilityZoneは末尾(1a, 1c...)のみ取得する
-			spl := strings.Split(*i.Placement.AvailabilityZone, "-")
-			az := spl[2]
+	images, err := c.DescribeImages(&ec2.DescribeImagesInput{ImageIds: []string{*i.ImageId}})
+	if err == nil && len(images) > 0 {
+		d.ImageName = images[0].Name
+	}
 
-			list = append(list, Instance{
-				Name:             name,
-				InstanceId:       *i.InstanceId,
-				InstanceType:     string(i.InstanceType),
-				Lifecycle:        string(i.InstanceLifecycle),
-				PrivateIpAddress: priip,
-				PublicIpAddress:  pubip,
-				State:            string(i.State.Name),
-				KeyName:          key,
-				AvailabilityZone: az,
-				LaunchTime:       i.LaunchTime.String(),
-			})
+	if i.SubnetId != nil {
+		d.SubnetId = *i.SubnetId
+		subnets, err := c.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: []string{*i.SubnetId}})
+		if err != nil {
+			return nil, err
+		}
+		if len(subnets) > 0 {
+			d.SubnetName = subnets[0].Name
 		}
 	}
 
-	sort.Slice(list, func(i, j int) bool {
-		return list[i].Name < list[j].Name
+	if i.VpcId != nil {
+		d.VpcId = *i.VpcId
+		vpcs, err := c.DescribeVpcs(&ec2.DescribeVpcsInput{VpcIds: []string{*i.VpcId}})
+		if err != nil {
+			return nil, err
+		}
+		if len(vpcs) > 0 {
+			d.VpcName = vpcs[0].Name
+		}
+	}
+
+	volumeIds := []string{}
+	for _, b := range i.BlockDeviceMappings {
+		if b.Ebs != nil {
+			volumeIds = append(volumeIds, *b.Ebs.VolumeId)
+		}
+	}
+	if len(volumeIds) > 0 {
+		d.Volumes, err = c.DescribeVolumes(&ec2.DescribeVolumesInput{VolumeIds: volumeIds})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	groupIds := []string{}
+	for _, g := range i.SecurityGroups {
+		groupIds = append(groupIds, *g.GroupId)
+	}
+	if len(groupIds) > 0 {
+		d.SecurityGroups, err = c.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{GroupIds: groupIds})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
+// DescribeUserData returns base64 encoded user data of the instance (empty when not set).
+func (c *EC2) DescribeUserData(id string) (string, error) {
+	output, err := c.Client.DescribeInstanceAttribute(context.TODO(), &ec2.DescribeInstanceAttributeInput{
+		InstanceId: aws.String(id),
+		Attribute:  types.InstanceAttributeNameUserData,
 	})
+	if err != nil {
+		return "", fmt.Errorf("describe instance attribute: %v", err)
+	}
 
-	return list, nil
+	if output.UserData == nil || output.UserData.Value == nil {
+		return "", nil
+	}
+
+	return *output.UserData.Value, nil
+}
+
+// GetConsoleOutput returns base64 encoded console output of the instance (empty when not available yet).
+func (c *EC2) GetConsoleOutput(input *ec2.GetConsoleOutputInput) (string, error) {
+	output, err := c.Client.GetConsoleOutput(context.TODO(), input)
+	if err != nil {
+		return "", fmt.Errorf("get console output: %v", err)
+	}
+
+	if output.Output == nil {
+		return "", nil
+	}
+
+	return *output.Output, nil
+}
+
+// newInstance returns Instance structure converted from types.Instance.
+func newInstance(i types.Instance) Instance {
+	priip := "None"
+	if i.PrivateIpAddress != nil {
+		priip = *i.PrivateIpAddress
+	}
+
+	pubip := "None"
+	if i.PublicIpAddress != nil {
+		pubip = *i.PublicIpAddress
+	}
+
+	key := "None"
+	if i.KeyName != nil {
+		key = *i.KeyName
+	}
+
+	// AvailabilityZoneは末尾(1a, 1c...)のみ取得する
+	spl := strings.Split(*i.Placement.AvailabilityZone, "-")
+	az := spl[2]
+
+	groups := []string{}
+	for _, g := range i.SecurityGroups {
+		groups = append(groups, *g.GroupId)
+	}
+
+	return Instance{
+		Name:             nameTag(i.Tags),
+		InstanceId:       *i.InstanceId,
+		InstanceType:     string(i.InstanceType),
+		Lifecycle:        string(i.InstanceLifecycle),
+		PrivateIpAddress: priip,
+		PublicIpAddress:  pubip,
+		State:            string(i.State.Name),
+		KeyName:          key,
+		AvailabilityZone: az,
+		LaunchTime:       i.LaunchTime.String(),
+		ImageId:          *i.ImageId,
+		SecurityGroups:   groups,
+	}
+}
+
+// nameTag returns the value of Name tag, or empty string when it is not tagged.
+func nameTag(tags []types.Tag) string {
+	for _, t := range tags {
+		if *t.Key == "Name" {
+			return *t.Value
+		}
+	}
+
+	return ""
+}
+
+// StartInstances starts instances.
+// With DryRun, nil is returned when the caller has the required permissions.
+func (c *EC2) StartInstances(input *ec2.StartInstancesInput) error {
+	if _, err := c.Client.StartInstances(context.TODO(), input); err != nil && !isDryRunOperation(err) {
+		return fmt.Errorf("start instances: %v", err)
+	}
+
+	return nil
+}
+
+// StopInstances stops (or hibernates) instances.
+// With DryRun, nil is returned when the caller has the required permissions.
+func (c *EC2) StopInstances(input *ec2.StopInstancesInput) error {
+	if _, err := c.Client.StopInstances(context.TODO(), input); err != nil && !isDryRunOperation(err) {
+		return fmt.Errorf("stop instances: %v", err)
+	}
+
+	return nil
+}
+
+// RebootInstances reboots instances.
+// With DryRun, nil is returned when the caller has the required permissions.
+func (c *EC2) RebootInstances(input *ec2.RebootInstancesInput) error {
+	if _, err := c.Client.RebootInstances(context.TODO(), input); err != nil && !isDryRunOperation(err) {
+		return fmt.Errorf("reboot instances: %v", err)
+	}
+
+	return nil
+}
+
+// TerminateInstances terminates instances.
+// With DryRun, nil is returned when the caller has the required permissions.
+func (c *EC2) TerminateInstances(input *ec2.TerminateInstancesInput) error {
+	if _, err := c.Client.TerminateInstances(context.TODO(), input); err != nil && !isDryRunOperation(err) {
+		return fmt.Errorf("terminate instances: %v", err)
+	}
+
+	return nil
+}
+
+// WaitInstanceState polls instances until all of them reach state.
+func (c *EC2) WaitInstanceState(ids []string, state string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	last := map[string]string{}
+
+	for {
+		instances, err := c.DescribeInstances(&ec2.DescribeInstancesInput{InstanceIds: ids})
+		if err != nil {
+			return err
+		}
+
+		done := true
+		for _, i := range instances {
+			if last[i.InstanceId] != i.State {
+				fmt.Printf("%s\t%s\t%s\n", i.InstanceId, i.Name, i.State)
+				last[i.InstanceId] = i.State
+			}
+
+			if i.State != state {
+				done = false
+			}
+		}
+
+		if done {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for instances to be %s", state)
+		}
+
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// isDryRunOperation reports whether err is the DryRunOperation response,
+// which means the request would have succeeded without DryRun.
+func isDryRunOperation(err error) bool {
+	var ae smithy.APIError
+	return errors.As(err, &ae) && ae.ErrorCode() == "DryRunOperation"
 }
 
 func PrintInstances(wrt io.Writer, resources []Instance) error {
@@ -132,6 +408,95 @@ func PrintInstances(wrt io.Writer, resources []Instance) error {
 	return nil
 }
 
+// PrintInstanceDetail prints InstanceDetail structure as sections.
+func PrintInstanceDetail(wrt io.Writer, d *InstanceDetail) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+
+	withName := func(id, name string) string {
+		if len(name) == 0 {
+			return id
+		}
+		return id + " (" + name + ")"
+	}
+
+	imdsv2 := "optional"
+	if d.HttpTokens == "required" {
+		imdsv2 = "required"
+	}
+
+	section := func(title string, rows ...[]string) error {
+		if _, err := fmt.Fprintf(w, "\n\x1b[35m[%s]\x1b[0m\n", title); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+		for _, r := range rows {
+			if _, err := fmt.Fprintln(w, strings.Join(r, "\t")); err != nil {
+				return fmt.Errorf("%v", err)
+			}
+		}
+		return nil
+	}
+
+	i := d.Instance
+	if err := section("Instance",
+		[]string{"Name", i.Name},
+		[]string{"InstanceId", i.InstanceId},
+		[]string{"InstanceType", i.InstanceType},
+		[]string{"State", i.State},
+		[]string{"AZ", i.AvailabilityZone},
+		[]string{"LaunchTime", i.LaunchTime},
+		[]string{"KeyName", i.KeyName},
+		[]string{"AMI", withName(d.ImageId, d.ImageName)},
+		[]string{"VPC", withName(d.VpcId, d.VpcName)},
+		[]string{"Subnet", withName(d.SubnetId, d.SubnetName)},
+		[]string{"IAMInstanceProfile", d.InstanceProfile},
+		[]string{"IMDSv2", imdsv2 + " (endpoint: " + d.HttpEndpoint + ", hop limit: " + d.HttpHopLimit + ")"},
+		[]string{"SSMPingStatus", d.SsmPingStatus},
+	); err != nil {
+		return err
+	}
+
+	tags := [][]string{{"Key", "Value"}}
+	for _, t := range d.Tags {
+		tags = append(tags, []string{t[0], t[1]})
+	}
+	if err := section("Tags", tags...); err != nil {
+		return err
+	}
+
+	enis := [][]string{{"NetworkInterfaceId", "SubnetId", "PrivateIPs", "PublicIPs", "SecurityGroups"}}
+	for _, n := range d.NetworkInterfaces {
+		enis = append(enis, []string{n.InstanceEniTabString()})
+	}
+	if err := section("Network Interfaces", enis...); err != nil {
+		return err
+	}
+
+	volumes := [][]string{{"VolumeId", "Device", "Size", "VolumeType", "IOPS", "Encrypted", "State"}}
+	for _, v := range d.Volumes {
+		volumes = append(volumes, []string{v.VolumeTabString()})
+	}
+	if err := section("Volumes", volumes...); err != nil {
+		return err
+	}
+
+	groups := [][]string{}
+	for _, g := range d.SecurityGroups {
+		groups = append(groups, []string{withName(g.GroupId, g.GroupName), "Protocol", "Ports", "Source", "Description"})
+		for _, r := range g.Inbound {
+			groups = append(groups, []string{"", r.RuleTabString()})
+		}
+	}
+	if err := section("Security Groups (Inbound)", groups...); err != nil {
+		return err
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
 func (i *Instance) Ec2TabString() string {
 	fields := []string{
 		i.Name,
@@ -18,6 +18,18 @@ func (m *EcsMockAPI) DescribeClusters(ctx context.Context, input *ecs.DescribeCl
 	return m.Output, m.Error
 }
 
+func (m *EcsMockAPI) ListClusters(ctx context.Context, input *ecs.ListClustersInput, opts ...func(*ecs.Options)) (*ecs.ListClustersOutput, error) {
+	arns := []string{}
+	for _, c := range m.Output.Clusters {
+		arns = append(arns, "arn:aws:ecs:ap-northeast-1:123456789012:cluster/"+*c.ClusterName)
+	}
+	return &ecs.ListClustersOutput{ClusterArns: arns}, m.Error
+}
+
+func (m *EcsMockAPI) ListServices(ctx context.Context, input *ecs.ListServicesInput, opts ...func(*ecs.Options)) (*ecs.ListServicesOutput, error) {
+	return &ecs.ListServicesOutput{}, m.Error
+}
+
 func TestGetClusters(t *testing.T) {
 	var clusterName string = "test_cluster"
 
@@ -26,6 +38,7 @@ func TestGetClusters(t *testing.T) {
 			Clusters: []types.Cluster{
 				{
 					ClusterName: aws.String(clusterName),
+					Status:      aws.String("ACTIVE"),
 				},
 			},
 		},
@@ -41,7 +54,7 @@ func TestGetClusters(t *testing.T) {
 		t.Errorf("Clusters length should be 1, but got %v", len(clusters))
 	}
 
-	if clusters[0] != "test_cluster" {
-		t.Errorf("ClusterName should be test, but got %v", clusters[0])
+	if clusters[0].Name != "test_cluster" {
+		t.Errorf("ClusterName should be test, but got %v", clusters[0].Name)
 	}
 }
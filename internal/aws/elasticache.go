@@ -35,35 +35,51 @@ type CacheNode struct {
 	CurrentRole        string
 	CacheClusterStatus string
 	CacheNodeStatus    string
+	Endpoints          []string
 }
 
 // DescribeCacheClusters returns slice CacheNode structure.
 func (c *ElastiCache) DescribeCacheClusters(input *elasticache.DescribeCacheClustersInput) ([]CacheNode, error) {
-	ctx := context.TODO()
-	output, err := c.Client.DescribeCacheClusters(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("describe cache cluster: %v", err)
-	}
+	list := []CacheNode{}
+	paginator := elasticache.NewDescribeCacheClustersPaginator(c.Client, input)
 
-	if len(output.CacheClusters) == 0 {
-		return nil, fmt.Errorf("no resources")
-	}
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe cache cluster: %v", err)
+		}
 
-	list := []CacheNode{}
-	for _, cc := range output.CacheClusters {
-		replicationGroupId := "None"
-		if cc.ReplicationGroupId != nil {
-			replicationGroupId = *cc.ReplicationGroupId
+		for _, cc := range output.CacheClusters {
+			replicationGroupId := "None"
+			if cc.ReplicationGroupId != nil {
+				replicationGroupId = *cc.ReplicationGroupId
+			}
+
+			// node endpoints are returned only with ShowCacheNodeInfo
+			endpoints := []string{}
+			if cc.ConfigurationEndpoint != nil && cc.ConfigurationEndpoint.Address != nil {
+				endpoints = append(endpoints, *cc.ConfigurationEndpoint.Address)
+			}
+			for _, n := range cc.CacheNodes {
+				if n.Endpoint != nil && n.Endpoint.Address != nil {
+					endpoints = append(endpoints, *n.Endpoint.Address)
+				}
+			}
+
+			list = append(list, CacheNode{
+				ReplicationGroupId: replicationGroupId,
+				CacheClusterId:     *cc.CacheClusterId,
+				CacheNodeType:      *cc.CacheNodeType,
+				Engine:             *cc.Engine,
+				EngineVersion:      *cc.EngineVersion,
+				CacheClusterStatus: *cc.CacheClusterStatus,
+				Endpoints:          endpoints,
+			})
 		}
+	}
 
-		list = append(list, CacheNode{
-			ReplicationGroupId: replicationGroupId,
-			CacheClusterId:     *cc.CacheClusterId,
-			CacheNodeType:      *cc.CacheNodeType,
-			Engine:             *cc.Engine,
-			EngineVersion:      *cc.EngineVersion,
-			CacheClusterStatus: *cc.CacheClusterStatus,
-		})
+	if len(list) == 0 {
+		return nil, ErrNoResources
 	}
 
 	sort.Slice(list, func(i, j int) bool {
@@ -105,6 +121,37 @@ func (c *ElastiCache) DescribeReplicationGroups(input *elasticache.DescribeRepli
 	return node, nil
 }
 
+// ListReplicationGroupEndpoints returns primary, reader and configuration endpoints of each replication group.
+func (c *ElastiCache) ListReplicationGroupEndpoints(input *elasticache.DescribeReplicationGroupsInput) (map[string][]string, error) {
+	m := map[string][]string{}
+	paginator := elasticache.NewDescribeReplicationGroupsPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe replication groups: %v", err)
+		}
+
+		for _, rg := range output.ReplicationGroups {
+			endpoints := []string{}
+			if rg.ConfigurationEndpoint != nil && rg.ConfigurationEndpoint.Address != nil {
+				endpoints = append(endpoints, *rg.ConfigurationEndpoint.Address)
+			}
+			for _, ng := range rg.NodeGroups {
+				if ng.PrimaryEndpoint != nil && ng.PrimaryEndpoint.Address != nil {
+					endpoints = append(endpoints, *ng.PrimaryEndpoint.Address)
+				}
+				if ng.ReaderEndpoint != nil && ng.ReaderEndpoint.Address != nil {
+					endpoints = append(endpoints, *ng.ReaderEndpoint.Address)
+				}
+			}
+			m[*rg.ReplicationGroupId] = endpoints
+		}
+	}
+
+	return m, nil
+}
+
 func PrintNodes(wrt io.Writer, resources []CacheNode) error {
 	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
 	header := []string{
@@ -26,32 +26,48 @@ func NewElbClient(profile, region string) *ELB {
 
 // Balancer structure is elb information.
 type Balancer struct {
-	Name    string
-	DNSName string
-	Scheme  string
-	Type    string
+	Name           string
+	Arn            string
+	DNSName        string
+	Scheme         string
+	Type           string
+	State          string
+	SecurityGroups []string
+	Instances      []string
 }
 
 // DescribeLoadBalancers returns slice Balancer structure.
 func (c *ELB) DescribeLoadBalancers(input *elb.DescribeLoadBalancersInput) ([]Balancer, error) {
-	ctx := context.TODO()
-	output, err := c.Client.DescribeLoadBalancers(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("describe load balancers: %v", err)
-	}
+	list := []Balancer{}
+	paginator := elb.NewDescribeLoadBalancersPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe load balancers: %v", err)
+		}
 
-	if len(output.LoadBalancerDescriptions) == 0 {
-		return nil, fmt.Errorf("no resources")
+		for _, i := range output.LoadBalancerDescriptions {
+			instances := []string{}
+			for _, n := range i.Instances {
+				instances = append(instances, *n.InstanceId)
+			}
+
+			list = append(list, Balancer{
+				Name:           *i.LoadBalancerName,
+				Arn:            "None",
+				DNSName:        *i.DNSName,
+				Scheme:         *i.Scheme,
+				Type:           "classic",
+				State:          "None",
+				SecurityGroups: i.SecurityGroups,
+				Instances:      instances,
+			})
+		}
 	}
 
-	list := []Balancer{}
-	for _, i := range output.LoadBalancerDescriptions {
-		list = append(list, Balancer{
-			Name:    *i.LoadBalancerName,
-			DNSName: *i.DNSName,
-			Scheme:  *i.Scheme,
-			Type:    "classic",
-		})
+	if len(list) == 0 {
+		return nil, ErrNoResources
 	}
 
 	sort.Slice(list, func(i, j int) bool {
@@ -75,25 +91,35 @@ func NewElbV2Client(profile, region string) *ELBV2 {
 
 // DescribeLoadBalancersV2 returns slice Balancer structure.
 func (c *ELBV2) DescribeLoadBalancersV2(input *elbv2.DescribeLoadBalancersInput) ([]Balancer, error) {
-	ctx := context.TODO()
-	output, err := c.Client.DescribeLoadBalancers(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("describe load balancers v2: %v", err)
-	}
+	list := []Balancer{}
+	paginator := elbv2.NewDescribeLoadBalancersPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe load balancers v2: %v", err)
+		}
 
-	if len(output.LoadBalancers) == 0 {
-		return nil, fmt.Errorf("no resources")
+		for _, i := range output.LoadBalancers {
+			state := "None"
+			if i.State != nil {
+				state = string(i.State.Code)
+			}
+
+			list = append(list, Balancer{
+				Name:           *i.LoadBalancerName,
+				Arn:            *i.LoadBalancerArn,
+				DNSName:        *i.DNSName,
+				Scheme:         string(i.Scheme),
+				Type:           string(i.Type),
+				State:          state,
+				SecurityGroups: i.SecurityGroups,
+			})
+		}
 	}
 
-	list := []Balancer{}
-	for _, i := range output.LoadBalancers {
-
-		list = append(list, Balancer{
-			Name:    *i.LoadBalancerName,
-			DNSName: *i.DNSName,
-			Scheme:  string(i.Scheme),
-			Type:    string(i.Type),
-		})
+	if len(list) == 0 {
+		return nil, ErrNoResources
 	}
 
 	sort.Slice(list, func(i, j int) bool {
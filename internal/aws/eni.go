@@ -0,0 +1,360 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	"github.com/sfuruya0612/snatch/internal/mapping"
+)
+
+// NetworkInterface structure is network interface information.
+type NetworkInterface struct {
+	NetworkInterfaceId string
+	InterfaceType      string
+	Status             string
+	Description        string
+	RequesterId        string
+	InstanceId         string
+	VpcId              string
+	SubnetId           string
+	PrivateIps         []string
+	PublicIps          []string
+	SecurityGroups     []string
+}
+
+// DescribeNetworkInterfaces returns slice NetworkInterface structure.
+func (c *EC2) DescribeNetworkInterfaces(input *ec2.DescribeNetworkInterfacesInput) ([]NetworkInterface, error) {
+	list := []NetworkInterface{}
+	paginator := ec2.NewDescribeNetworkInterfacesPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("describe network interfaces: %v", err)
+		}
+
+		for _, n := range output.NetworkInterfaces {
+			description := "None"
+			if n.Description != nil && len(*n.Description) > 0 {
+				description = *n.Description
+			}
+
+			requester := "None"
+			if n.RequesterId != nil {
+				requester = *n.RequesterId
+			}
+
+			instance := "None"
+			if n.Attachment != nil && n.Attachment.InstanceId != nil {
+				instance = *n.Attachment.InstanceId
+			}
+
+			eni := NetworkInterface{
+				NetworkInterfaceId: *n.NetworkInterfaceId,
+				InterfaceType:      string(n.InterfaceType),
+				Status:             string(n.Status),
+				Description:        description,
+				RequesterId:        requester,
+				InstanceId:         instance,
+				VpcId:              *n.VpcId,
+				SubnetId:           *n.SubnetId,
+			}
+
+			for _, p := range n.PrivateIpAddresses {
+				eni.PrivateIps = append(eni.PrivateIps, *p.PrivateIpAddress)
+				if p.Association != nil && p.Association.PublicIp != nil {
+					eni.PublicIps = append(eni.PublicIps, *p.Association.PublicIp)
+				}
+			}
+
+			for _, g := range n.Groups {
+				eni.SecurityGroups = append(eni.SecurityGroups, *g.GroupId)
+			}
+
+			list = append(list, eni)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].NetworkInterfaceId < list[j].NetworkInterfaceId
+	})
+
+	return list, nil
+}
+
+// OwnerService returns the service which created the network interface, guessed from
+// the interface type, requester and description.
+func (n *NetworkInterface) OwnerService() string {
+	switch n.InterfaceType {
+	case "interface", "":
+	case "network_load_balancer", "gateway_load_balancer", "gateway_load_balancer_endpoint":
+		return "elb"
+	default:
+		return n.InterfaceType
+	}
+
+	switch n.RequesterId {
+	case "amazon-elb":
+		return "elb"
+	case "amazon-rds":
+		return "rds"
+	case "amazon-elasticache":
+		return "elasticache"
+	case "amazon-redshift":
+		return "redshift"
+	}
+
+	switch {
+	case strings.HasPrefix(n.Description, "AWS Lambda VPC ENI"):
+		return "lambda"
+	case strings.Contains(n.Description, ":ecs:") || strings.HasPrefix(n.Description, "ecs-"):
+		return "ecs"
+	case strings.HasPrefix(n.Description, "ELB "):
+		return "elb"
+	case strings.HasPrefix(n.Description, "RDSNetworkInterface"):
+		return "rds"
+	case strings.HasPrefix(n.Description, "EFS mount target"):
+		return "efs"
+	case n.InstanceId != "None":
+		return "ec2"
+	}
+
+	return "unknown"
+}
+
+func PrintNetworkInterfaces(wrt io.Writer, resources []NetworkInterface) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"NetworkInterfaceId",
+		"Type",
+		"Owner",
+		"Status",
+		"InstanceId",
+		"SubnetId",
+		"PrivateIPs",
+		"PublicIPs",
+		"SecurityGroups",
+		"Description",
+		"Flag",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.EniTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (n *NetworkInterface) EniTabString() string {
+	public := "None"
+	if len(n.PublicIps) > 0 {
+		public = strings.Join(n.PublicIps, ",")
+	}
+
+	// detached interfaces left over by lambda or ecs are not cleaned up automatically
+	flag := "None"
+	if n.Status == "available" {
+		flag = "orphan"
+	}
+
+	fields := []string{
+		n.NetworkInterfaceId,
+		n.InterfaceType,
+		n.OwnerService(),
+		n.Status,
+		n.InstanceId,
+		n.SubnetId,
+		strings.Join(n.PrivateIps, ","),
+		public,
+		strings.Join(n.SecurityGroups, ","),
+		n.Description,
+		flag,
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// Address structure is elastic ip information.
+type Address struct {
+	PublicIp           string
+	AllocationId       string
+	Name               string
+	Domain             string
+	InstanceId         string
+	NetworkInterfaceId string
+	PrivateIp          string
+	Association        string
+}
+
+// DescribeAddresses returns slice Address structure.
+// Association is filled with the instance id or network interface id.
+func (c *EC2) DescribeAddresses(input *ec2.DescribeAddressesInput) ([]Address, error) {
+	output, err := c.Client.DescribeAddresses(context.TODO(), input)
+	if err != nil {
+		return nil, fmt.Errorf("describe addresses: %v", err)
+	}
+
+	list := []Address{}
+	for _, a := range output.Addresses {
+		allocation := "None"
+		if a.AllocationId != nil {
+			allocation = *a.AllocationId
+		}
+
+		instance := "None"
+		if a.InstanceId != nil && len(*a.InstanceId) > 0 {
+			instance = *a.InstanceId
+		}
+
+		eni := "None"
+		if a.NetworkInterfaceId != nil {
+			eni = *a.NetworkInterfaceId
+		}
+
+		private := "None"
+		if a.PrivateIpAddress != nil {
+			private = *a.PrivateIpAddress
+		}
+
+		association := "None"
+		switch {
+		case instance != "None":
+			association = instance
+		case eni != "None":
+			association = eni
+		}
+
+		list = append(list, Address{
+			PublicIp:           *a.PublicIp,
+			AllocationId:       allocation,
+			Name:               nameTag(a.Tags),
+			Domain:             string(a.Domain),
+			InstanceId:         instance,
+			NetworkInterfaceId: eni,
+			PrivateIp:          private,
+			Association:        association,
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list, nil
+}
+
+// ResolveAddressAssociations replaces Association of each address with a readable owner,
+// such as the instance name, the NAT gateway id or the owner service of the network interface.
+func ResolveAddressAssociations(addresses []Address, instances []Instance, enis []NetworkInterface) {
+	names := map[string]string{}
+	for _, i := range instances {
+		names[i.InstanceId] = i.Name
+	}
+
+	owners := map[string]string{}
+	for _, n := range enis {
+		owner := n.OwnerService()
+		if owner == "nat_gateway" {
+			// e.g. "Interface for NAT Gateway nat-0123456789abcdef0"
+			if f := strings.Fields(n.Description); len(f) > 0 && strings.HasPrefix(f[len(f)-1], "nat-") {
+				owner = "nat:" + f[len(f)-1]
+			}
+		}
+		owners[n.NetworkInterfaceId] = owner
+	}
+
+	for i, a := range addresses {
+		switch {
+		case a.InstanceId != "None":
+			addresses[i].Association = "ec2:" + names[a.InstanceId] + " (" + a.InstanceId + ")"
+		case a.NetworkInterfaceId != "None":
+			if owner, ok := owners[a.NetworkInterfaceId]; ok && strings.HasPrefix(owner, "nat:") {
+				addresses[i].Association = owner
+			} else if ok {
+				addresses[i].Association = owner + ":" + a.NetworkInterfaceId
+			}
+		}
+	}
+}
+
+func PrintAddresses(wrt io.Writer, resources []Address) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"PublicIP",
+		"AllocationId",
+		"Name",
+		"Association",
+		"NetworkInterfaceId",
+		"PrivateIP",
+		"Flag",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.AddressTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (a *Address) AddressTabString() string {
+	flag := "None"
+	if a.Association == "None" {
+		flag = "unassociated ($" + strconv.FormatFloat(mapping.PublicIpv4PerHour*24*30, 'f', 2, 64) + "/month)"
+	}
+
+	fields := []string{
+		a.PublicIp,
+		a.AllocationId,
+		a.Name,
+		a.Association,
+		a.NetworkInterfaceId,
+		a.PrivateIp,
+		flag,
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+func (n *NetworkInterface) InstanceEniTabString() string {
+	public := "None"
+	if len(n.PublicIps) > 0 {
+		public = strings.Join(n.PublicIps, ",")
+	}
+
+	fields := []string{
+		n.NetworkInterfaceId,
+		n.SubnetId,
+		strings.Join(n.PrivateIps, ","),
+		public,
+		strings.Join(n.SecurityGroups, ","),
+	}
+
+	return strings.Join(fields, "\t")
+}
@@ -0,0 +1,24 @@
+package aws
+
+import "testing"
+
+func TestOwnerService(t *testing.T) {
+	cases := []struct {
+		eni  NetworkInterface
+		want string
+	}{
+		{NetworkInterface{InterfaceType: "nat_gateway", Description: "Interface for NAT Gateway nat-0123"}, "nat_gateway"},
+		{NetworkInterface{InterfaceType: "network_load_balancer"}, "elb"},
+		{NetworkInterface{InterfaceType: "interface", RequesterId: "amazon-rds", InstanceId: "None"}, "rds"},
+		{NetworkInterface{InterfaceType: "interface", Description: "AWS Lambda VPC ENI-func-abc", InstanceId: "None"}, "lambda"},
+		{NetworkInterface{InterfaceType: "interface", Description: "arn:aws:ecs:ap-northeast-1:123456789012:attachment/abc", InstanceId: "None"}, "ecs"},
+		{NetworkInterface{InterfaceType: "interface", Description: "None", InstanceId: "i-0123"}, "ec2"},
+		{NetworkInterface{InterfaceType: "interface", Description: "None", InstanceId: "None"}, "unknown"},
+	}
+
+	for _, c := range cases {
+		if got := c.eni.OwnerService(); got != c.want {
+			t.Errorf("%v should be %s, but got %s", c.eni, c.want, got)
+		}
+	}
+}
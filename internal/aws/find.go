@@ -0,0 +1,122 @@
+package aws
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+)
+
+// Match structure is a resource field which matches the search term.
+type Match struct {
+	Service  string
+	Resource string
+	Field    string
+	Value    string
+}
+
+// Field structure is a resource field to search and how it is compared with the term.
+type Field struct {
+	Name      string
+	Value     string
+	Substring bool
+}
+
+// Exact returns a field which matches when one of its comma separated values equals the term, e.g. IDs and IPs.
+func Exact(name, value string) Field {
+	return Field{Name: name, Value: value}
+}
+
+// Substring returns a field which matches when it contains the term, e.g. DNS names.
+// An IP term still has to equal one of the values, so 10.1.2.3 does not match 10.1.2.30.
+func Substring(name, value string) Field {
+	return Field{Name: name, Value: value, Substring: true}
+}
+
+// MatchFields returns a Match for each field which matches term, case insensitively.
+// Fields with empty values are skipped.
+func MatchFields(term, service, resource string, fields ...Field) []Match {
+	term = strings.ToLower(term)
+	ip := net.ParseIP(term) != nil
+
+	list := []Match{}
+	for _, f := range fields {
+		if len(f.Value) > 0 && f.matches(term, ip) {
+			list = append(list, Match{
+				Service:  service,
+				Resource: resource,
+				Field:    f.Name,
+				Value:    f.Value,
+			})
+		}
+	}
+
+	return list
+}
+
+func (f Field) matches(term string, ip bool) bool {
+	value := strings.ToLower(f.Value)
+	if f.Substring && !ip {
+		return strings.Contains(value, term)
+	}
+
+	values := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	return slices.Contains(values, term)
+}
+
+// SortMatches sorts matches by service, resource and field.
+func SortMatches(matches []Match) {
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Service != matches[j].Service {
+			return matches[i].Service < matches[j].Service
+		}
+		if matches[i].Resource != matches[j].Resource {
+			return matches[i].Resource < matches[j].Resource
+		}
+		return matches[i].Field < matches[j].Field
+	})
+}
+
+func PrintMatches(wrt io.Writer, resources []Match) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"Service",
+		"Resource",
+		"Field",
+		"Value",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.MatchTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (m *Match) MatchTabString() string {
+	fields := []string{
+		m.Service,
+		m.Resource,
+		m.Field,
+		m.Value,
+	}
+
+	return strings.Join(fields, "\t")
+}
@@ -0,0 +1,43 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchFields(t *testing.T) {
+	got := MatchFields("App-DB", "rds", "app-db",
+		Exact("DBInstanceIdentifier", "app-db"),
+		Substring("Endpoint", "app-db.xxxx.ap-northeast-1.rds.amazonaws.com:5432"),
+		Exact("Engine", ""),
+	)
+
+	want := []Match{
+		{Service: "rds", Resource: "app-db", Field: "DBInstanceIdentifier", Value: "app-db"},
+		{Service: "rds", Resource: "app-db", Field: "Endpoint", Value: "app-db.xxxx.ap-northeast-1.rds.amazonaws.com:5432"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MatchFields should be %v, but got %v", want, got)
+	}
+
+	cases := []struct {
+		term  string
+		field Field
+		want  bool
+	}{
+		{"10.1.2.3", Exact("PrivateIPs", "10.1.2.4,10.1.2.3"), true},
+		{"10.1.2.3", Exact("PrivateIP", "10.1.2.30"), false},
+		{"10.1.2.3", Exact("PrivateIP", "110.1.2.3"), false},
+		{"10.1.2.3", Substring("Value", "10.1.2.30"), false},
+		{"10.1.2.3", Substring("Description", "Primary 10.1.2.3"), true},
+		{"i-0123", Exact("InstanceId", "i-01234567"), false},
+		{"my-alb", Substring("DNSName", "my-alb-1234567890.ap-northeast-1.elb.amazonaws.com"), true},
+	}
+
+	for _, c := range cases {
+		if got := len(MatchFields(c.term, "ec2", "r", c.field)) > 0; got != c.want {
+			t.Errorf("%s in %s should be %v, but got %v", c.term, c.field.Value, c.want, got)
+		}
+	}
+}
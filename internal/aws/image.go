@@ -0,0 +1,164 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// Image structure is ami information.
+type Image struct {
+	ImageId      string
+	Name         string
+	State        string
+	CreationDate string
+	Snapshots    []string
+	UsedBy       []string
+}
+
+// DescribeImages returns slice Image structure.
+func (c *EC2) DescribeImages(input *ec2.DescribeImagesInput) ([]Image, error) {
+	list := []Image{}
+	paginator := ec2.NewDescribeImagesPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("describe images: %v", err)
+		}
+
+		for _, i := range output.Images {
+			name := "None"
+			if i.Name != nil {
+				name = *i.Name
+			}
+
+			created := "None"
+			if i.CreationDate != nil {
+				created = *i.CreationDate
+			}
+
+			snapshots := []string{}
+			for _, b := range i.BlockDeviceMappings {
+				if b.Ebs != nil && b.Ebs.SnapshotId != nil {
+					snapshots = append(snapshots, *b.Ebs.SnapshotId)
+				}
+			}
+
+			list = append(list, Image{
+				ImageId:      *i.ImageId,
+				Name:         name,
+				State:        string(i.State),
+				CreationDate: created,
+				Snapshots:    snapshots,
+			})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreationDate > list[j].CreationDate
+	})
+
+	return list, nil
+}
+
+// DeregisterImage deregisters the image.
+func (c *EC2) DeregisterImage(input *ec2.DeregisterImageInput) error {
+	if _, err := c.Client.DeregisterImage(context.TODO(), input); err != nil {
+		return fmt.Errorf("deregister image %s: %v", *input.ImageId, err)
+	}
+
+	return nil
+}
+
+// DeleteSnapshot deletes the snapshot.
+func (c *EC2) DeleteSnapshot(input *ec2.DeleteSnapshotInput) error {
+	if _, err := c.Client.DeleteSnapshot(context.TODO(), input); err != nil {
+		return fmt.Errorf("delete snapshot %s: %v", *input.SnapshotId, err)
+	}
+
+	return nil
+}
+
+// PruneImages returns images that are not used, created before cutoff,
+// and not one of the keep most recent images.
+func PruneImages(images []Image, cutoff time.Time, keep int) []Image {
+	sorted := make([]Image, len(images))
+	copy(sorted, images)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].CreationDate > sorted[j].CreationDate
+	})
+
+	list := []Image{}
+	for n, i := range sorted {
+		if n < keep || len(i.UsedBy) > 0 {
+			continue
+		}
+
+		created, err := time.Parse(time.RFC3339, i.CreationDate)
+		if err != nil || created.After(cutoff) {
+			continue
+		}
+
+		list = append(list, i)
+	}
+
+	return list
+}
+
+func PrintImages(wrt io.Writer, resources []Image) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"ImageId",
+		"Name",
+		"State",
+		"CreationDate",
+		"Snapshots",
+		"UsedBy",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.ImageTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (i *Image) ImageTabString() string {
+	snapshots := "None"
+	if len(i.Snapshots) > 0 {
+		snapshots = strings.Join(i.Snapshots, ",")
+	}
+
+	used := "None"
+	if len(i.UsedBy) > 0 {
+		used = strings.Join(i.UsedBy, ",")
+	}
+
+	fields := []string{
+		i.ImageId,
+		i.Name,
+		i.State,
+		i.CreationDate,
+		snapshots,
+		used,
+	}
+
+	return strings.Join(fields, "\t")
+}
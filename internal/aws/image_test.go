@@ -0,0 +1,43 @@
+package aws
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPruneImages(t *testing.T) {
+	images := []Image{
+		{ImageId: "ami-latest", CreationDate: "2024-03-01T00:00:00.000Z"},
+		{ImageId: "ami-used", CreationDate: "2024-02-01T00:00:00.000Z", UsedBy: []string{"ec2:web(i-1)"}},
+		{ImageId: "ami-old", CreationDate: "2024-01-01T00:00:00.000Z"},
+		{ImageId: "ami-lt", CreationDate: "2023-12-01T00:00:00.000Z", UsedBy: []string{"lt:web"}},
+		{ImageId: "ami-new", CreationDate: "2024-02-20T00:00:00.000Z"},
+	}
+	cutoff := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		keep int
+		want []string
+	}{
+		{0, []string{"ami-old"}},
+		{1, []string{"ami-old"}},
+		{4, []string{}},
+	}
+
+	for _, c := range cases {
+		got := []string{}
+		for _, i := range PruneImages(images, cutoff, c.keep) {
+			got = append(got, i.ImageId)
+		}
+
+		if len(got) != len(c.want) {
+			t.Errorf("keep %d: should be %v, but got %v", c.keep, c.want, got)
+			continue
+		}
+		for n := range got {
+			if got[n] != c.want[n] {
+				t.Errorf("keep %d: should be %v, but got %v", c.keep, c.want, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	"github.com/sfuruya0612/snatch/internal/util"
+)
+
+// InstanceTypeSpec structure is instance type specification.
+type InstanceTypeSpec struct {
+	InstanceType  string
+	Architectures []string
+	VCpus         int32
+	MemoryMiB     int64
+	Network       string
+	EbsBaseline   int32
+	EbsMaximum    int32
+	Features      []string
+}
+
+// DescribeInstanceTypes returns slice InstanceTypeSpec structure sorted by vCPU and memory.
+func (c *EC2) DescribeInstanceTypes(input *ec2.DescribeInstanceTypesInput) ([]InstanceTypeSpec, error) {
+	list := []InstanceTypeSpec{}
+	paginator := ec2.NewDescribeInstanceTypesPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe instance types: %v", err)
+		}
+
+		for _, t := range output.InstanceTypes {
+			spec := InstanceTypeSpec{
+				InstanceType: string(t.InstanceType),
+				Network:      "None",
+			}
+
+			if t.ProcessorInfo != nil {
+				for _, a := range t.ProcessorInfo.SupportedArchitectures {
+					spec.Architectures = append(spec.Architectures, string(a))
+				}
+			}
+
+			if t.VCpuInfo != nil && t.VCpuInfo.DefaultVCpus != nil {
+				spec.VCpus = *t.VCpuInfo.DefaultVCpus
+			}
+
+			if t.MemoryInfo != nil && t.MemoryInfo.SizeInMiB != nil {
+				spec.MemoryMiB = *t.MemoryInfo.SizeInMiB
+			}
+
+			if t.NetworkInfo != nil && t.NetworkInfo.NetworkPerformance != nil {
+				spec.Network = *t.NetworkInfo.NetworkPerformance
+			}
+
+			if t.EbsInfo != nil && t.EbsInfo.EbsOptimizedInfo != nil {
+				if t.EbsInfo.EbsOptimizedInfo.BaselineBandwidthInMbps != nil {
+					spec.EbsBaseline = *t.EbsInfo.EbsOptimizedInfo.BaselineBandwidthInMbps
+				}
+				if t.EbsInfo.EbsOptimizedInfo.MaximumBandwidthInMbps != nil {
+					spec.EbsMaximum = *t.EbsInfo.EbsOptimizedInfo.MaximumBandwidthInMbps
+				}
+			}
+
+			if t.Hypervisor == "nitro" {
+				spec.Features = append(spec.Features, "nitro")
+			}
+			if t.BareMetal != nil && *t.BareMetal {
+				spec.Features = append(spec.Features, "metal")
+			}
+			if t.BurstablePerformanceSupported != nil && *t.BurstablePerformanceSupported {
+				spec.Features = append(spec.Features, "burstable")
+			}
+			if t.InstanceStorageSupported != nil && *t.InstanceStorageSupported {
+				spec.Features = append(spec.Features, "instance-store")
+			}
+			if t.GpuInfo != nil {
+				spec.Features = append(spec.Features, "gpu")
+			}
+			if t.NetworkInfo != nil && t.NetworkInfo.EfaSupported != nil && *t.NetworkInfo.EfaSupported {
+				spec.Features = append(spec.Features, "efa")
+			}
+			if t.HibernationSupported != nil && *t.HibernationSupported {
+				spec.Features = append(spec.Features, "hibernation")
+			}
+			for _, u := range t.SupportedUsageClasses {
+				if u == "spot" {
+					spec.Features = append(spec.Features, "spot")
+				}
+			}
+
+			list = append(list, spec)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].VCpus != list[j].VCpus {
+			return list[i].VCpus < list[j].VCpus
+		}
+		if list[i].MemoryMiB != list[j].MemoryMiB {
+			return list[i].MemoryMiB < list[j].MemoryMiB
+		}
+		return list[i].InstanceType < list[j].InstanceType
+	})
+
+	return list, nil
+}
+
+func PrintInstanceTypes(wrt io.Writer, resources []InstanceTypeSpec) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"InstanceType",
+		"Arch",
+		"vCPU",
+		"Memory(GiB)",
+		"Network",
+		"EBS(Mbps)",
+		"Features",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.InstanceTypeTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (s *InstanceTypeSpec) InstanceTypeTabString() string {
+	ebs := "None"
+	if s.EbsMaximum > 0 {
+		ebs = strconv.Itoa(int(s.EbsBaseline)) + "/" + strconv.Itoa(int(s.EbsMaximum))
+	}
+
+	features := "None"
+	if len(s.Features) > 0 {
+		features = strings.Join(s.Features, ",")
+	}
+
+	fields := []string{
+		s.InstanceType,
+		strings.Join(s.Architectures, ","),
+		strconv.Itoa(int(s.VCpus)),
+		strconv.FormatFloat(float64(s.MemoryMiB)/1024, 'f', -1, 64),
+		s.Network,
+		ebs,
+		features,
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// SpotPrice structure is spot price at a point of time.
+type SpotPrice struct {
+	AvailabilityZone string
+	InstanceType     string
+	Price            float64
+	Timestamp        time.Time
+}
+
+// DescribeSpotPriceHistory returns slice SpotPrice structure sorted by time.
+func (c *EC2) DescribeSpotPriceHistory(input *ec2.DescribeSpotPriceHistoryInput) ([]SpotPrice, error) {
+	list := []SpotPrice{}
+	paginator := ec2.NewDescribeSpotPriceHistoryPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe spot price history: %v", err)
+		}
+
+		for _, p := range output.SpotPriceHistory {
+			price, err := strconv.ParseFloat(*p.SpotPrice, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parse spot price: %v", err)
+			}
+
+			list = append(list, SpotPrice{
+				AvailabilityZone: *p.AvailabilityZone,
+				InstanceType:     string(p.InstanceType),
+				Price:            price,
+				Timestamp:        *p.Timestamp,
+			})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Timestamp.Before(list[j].Timestamp)
+	})
+
+	return list, nil
+}
+
+// SpotPriceStats structure is spot price statistics of an availability zone.
+type SpotPriceStats struct {
+	AvailabilityZone string
+	InstanceType     string
+	Current          float64
+	Min              float64
+	Avg              float64
+	Max              float64
+	History          []float64
+}
+
+// SummarizeSpotPrices returns statistics per instance type and availability zone.
+// prices must be sorted by time. Avg and History are computed over the recorded
+// price changes and are not weighted by how long each price lasted.
+func SummarizeSpotPrices(prices []SpotPrice) []SpotPriceStats {
+	m := map[string]*SpotPriceStats{}
+	keys := []string{}
+	for _, p := range prices {
+		key := p.InstanceType + "/" + p.AvailabilityZone
+		s, ok := m[key]
+		if !ok {
+			s = &SpotPriceStats{
+				AvailabilityZone: p.AvailabilityZone,
+				InstanceType:     p.InstanceType,
+				Min:              p.Price,
+				Max:              p.Price,
+			}
+			m[key] = s
+			keys = append(keys, key)
+		}
+
+		s.Current = p.Price
+		s.Min = min(s.Min, p.Price)
+		s.Max = max(s.Max, p.Price)
+		s.History = append(s.History, p.Price)
+	}
+
+	sort.Strings(keys)
+
+	list := []SpotPriceStats{}
+	for _, k := range keys {
+		s := m[k]
+		sum := 0.0
+		for _, v := range s.History {
+			sum += v
+		}
+		s.Avg = sum / float64(len(s.History))
+		list = append(list, *s)
+	}
+
+	return list
+}
+
+func PrintSpotPriceStats(wrt io.Writer, resources []SpotPriceStats) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"InstanceType",
+		"AZ",
+		"Current",
+		"Min",
+		"Avg",
+		"Max",
+		"History",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.SpotPriceStatsTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (s *SpotPriceStats) SpotPriceStatsTabString() string {
+	fields := []string{
+		s.InstanceType,
+		s.AvailabilityZone,
+		strconv.FormatFloat(s.Current, 'f', 4, 64),
+		strconv.FormatFloat(s.Min, 'f', 4, 64),
+		strconv.FormatFloat(s.Avg, 'f', 4, 64),
+		strconv.FormatFloat(s.Max, 'f', 4, 64),
+		util.Sparkline(s.History, 40),
+	}
+
+	return strings.Join(fields, "\t")
+}
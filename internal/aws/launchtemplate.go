@@ -0,0 +1,241 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	"github.com/sfuruya0612/snatch/internal/util"
+)
+
+// LaunchTemplate structure is launch template information.
+type LaunchTemplate struct {
+	LaunchTemplateId   string
+	LaunchTemplateName string
+	DefaultVersion     string
+	LatestVersion      string
+	CreateTime         string
+}
+
+// DescribeLaunchTemplates returns slice LaunchTemplate structure.
+func (c *EC2) DescribeLaunchTemplates(input *ec2.DescribeLaunchTemplatesInput) ([]LaunchTemplate, error) {
+	list := []LaunchTemplate{}
+	paginator := ec2.NewDescribeLaunchTemplatesPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("describe launch templates: %v", err)
+		}
+
+		for _, t := range output.LaunchTemplates {
+			list = append(list, LaunchTemplate{
+				LaunchTemplateId:   *t.LaunchTemplateId,
+				LaunchTemplateName: *t.LaunchTemplateName,
+				DefaultVersion:     strconv.FormatInt(*t.DefaultVersionNumber, 10),
+				LatestVersion:      strconv.FormatInt(*t.LatestVersionNumber, 10),
+				CreateTime:         t.CreateTime.String(),
+			})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].LaunchTemplateName < list[j].LaunchTemplateName
+	})
+
+	return list, nil
+}
+
+// LaunchTemplateVersion structure is launch template version information.
+type LaunchTemplateVersion struct {
+	LaunchTemplateName string
+	Version            string
+	ImageId            string
+}
+
+// DescribeLaunchTemplateVersions returns slice LaunchTemplateVersion structure.
+func (c *EC2) DescribeLaunchTemplateVersions(input *ec2.DescribeLaunchTemplateVersionsInput) ([]LaunchTemplateVersion, error) {
+	list := []LaunchTemplateVersion{}
+	paginator := ec2.NewDescribeLaunchTemplateVersionsPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("describe launch template versions: %v", err)
+		}
+
+		for _, v := range output.LaunchTemplateVersions {
+			image := "None"
+			if v.LaunchTemplateData != nil && v.LaunchTemplateData.ImageId != nil {
+				image = *v.LaunchTemplateData.ImageId
+			}
+
+			list = append(list, LaunchTemplateVersion{
+				LaunchTemplateName: *v.LaunchTemplateName,
+				Version:            strconv.FormatInt(*v.VersionNumber, 10),
+				ImageId:            image,
+			})
+		}
+	}
+
+	return list, nil
+}
+
+// LaunchTemplateVersionDetail structure is resolved data of a launch template version.
+// Data is the launch template data flattened by util.Flatten, without UserData.
+// UserData is decoded.
+type LaunchTemplateVersionDetail struct {
+	LaunchTemplateName string
+	Version            string
+	DefaultVersion     bool
+	Description        string
+	CreateTime         string
+	CreatedBy          string
+	Data               map[string]string
+	UserData           string
+}
+
+// DescribeLaunchTemplateVersionDetail returns LaunchTemplateVersionDetail structure.
+// version is a version number, "$Latest" or "$Default".
+func (c *EC2) DescribeLaunchTemplateVersionDetail(name, version string) (*LaunchTemplateVersionDetail, error) {
+	output, err := c.Client.DescribeLaunchTemplateVersions(context.TODO(), &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateName: aws.String(name),
+		Versions:           []string{version},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe launch template versions: %v", err)
+	}
+
+	if len(output.LaunchTemplateVersions) == 0 {
+		return nil, ErrNoResources
+	}
+
+	v := output.LaunchTemplateVersions[0]
+
+	description := "None"
+	if v.VersionDescription != nil && len(*v.VersionDescription) > 0 {
+		description = *v.VersionDescription
+	}
+
+	createdBy := "None"
+	if v.CreatedBy != nil {
+		createdBy = *v.CreatedBy
+	}
+
+	detail := &LaunchTemplateVersionDetail{
+		LaunchTemplateName: *v.LaunchTemplateName,
+		Version:            strconv.FormatInt(*v.VersionNumber, 10),
+		DefaultVersion:     v.DefaultVersion != nil && *v.DefaultVersion,
+		Description:        description,
+		CreateTime:         v.CreateTime.String(),
+		CreatedBy:          createdBy,
+		Data:               map[string]string{},
+	}
+
+	if v.LaunchTemplateData == nil {
+		return detail, nil
+	}
+
+	if detail.Data, err = util.Flatten(v.LaunchTemplateData); err != nil {
+		return nil, err
+	}
+
+	if u, ok := detail.Data["UserData"]; ok {
+		delete(detail.Data, "UserData")
+		if detail.UserData, err = util.DecodeUserData(u); err != nil {
+			return nil, err
+		}
+	}
+
+	return detail, nil
+}
+
+func PrintLaunchTemplates(wrt io.Writer, resources []LaunchTemplate) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"Name",
+		"LaunchTemplateId",
+		"DefaultVersion",
+		"LatestVersion",
+		"CreateTime",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.LaunchTemplateTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (t *LaunchTemplate) LaunchTemplateTabString() string {
+	fields := []string{
+		t.LaunchTemplateName,
+		t.LaunchTemplateId,
+		t.DefaultVersion,
+		t.LatestVersion,
+		t.CreateTime,
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// PrintLaunchTemplateVersionDetail prints LaunchTemplateVersionDetail structure,
+// the flattened data sorted by key and the decoded user data.
+func PrintLaunchTemplateVersionDetail(wrt io.Writer, d *LaunchTemplateVersionDetail) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+
+	rows := [][]string{
+		{"Name", d.LaunchTemplateName},
+		{"Version", d.Version},
+		{"DefaultVersion", strconv.FormatBool(d.DefaultVersion)},
+		{"Description", d.Description},
+		{"CreateTime", d.CreateTime},
+		{"CreatedBy", d.CreatedBy},
+		{"", ""},
+	}
+
+	keys := []string{}
+	for k := range d.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		rows = append(rows, []string{k, d.Data[k]})
+	}
+
+	for _, r := range rows {
+		if _, err := fmt.Fprintln(w, strings.Join(r, "\t")); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	if len(d.UserData) > 0 {
+		if _, err := fmt.Fprintf(wrt, "\nUserData\n%s", d.UserData); err != nil {
+			return fmt.Errorf("user data: %v", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,286 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+// Listener structure is elb listener information.
+type Listener struct {
+	ListenerArn    string
+	Protocol       string
+	Port           int32
+	DefaultActions []ListenerAction
+}
+
+// ListenerAction structure is action of listener or rule.
+// Detail is the redirect location or the fixed response status code.
+type ListenerAction struct {
+	Type            string
+	TargetGroupArns []string
+	Detail          string
+}
+
+// ListenerRule structure is elb listener rule information.
+type ListenerRule struct {
+	Priority   string
+	Conditions []string
+	Actions    []ListenerAction
+}
+
+// TargetGroup structure is elb target group information.
+type TargetGroup struct {
+	TargetGroupArn string
+	Name           string
+	Protocol       string
+	Port           int32
+	TargetType     string
+}
+
+// Target structure is registered target and its health.
+type Target struct {
+	Id               string
+	Port             int32
+	AvailabilityZone string
+	State            string
+	Reason           string
+}
+
+// DescribeListeners returns slice Listener structure sorted by port.
+func (c *ELBV2) DescribeListeners(input *elbv2.DescribeListenersInput) ([]Listener, error) {
+	list := []Listener{}
+	paginator := elbv2.NewDescribeListenersPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe listeners: %v", err)
+		}
+
+		for _, l := range output.Listeners {
+			var port int32
+			if l.Port != nil {
+				port = *l.Port
+			}
+
+			list = append(list, Listener{
+				ListenerArn:    *l.ListenerArn,
+				Protocol:       string(l.Protocol),
+				Port:           port,
+				DefaultActions: newListenerActions(l.DefaultActions),
+			})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Port < list[j].Port
+	})
+
+	return list, nil
+}
+
+// DescribeRules returns slice ListenerRule structure without the default rule,
+// which is the same as the default actions of the listener.
+func (c *ELBV2) DescribeRules(input *elbv2.DescribeRulesInput) ([]ListenerRule, error) {
+	output, err := c.Client.DescribeRules(context.TODO(), input)
+	if err != nil {
+		return nil, fmt.Errorf("describe rules: %v", err)
+	}
+
+	list := []ListenerRule{}
+	for _, r := range output.Rules {
+		if r.IsDefault != nil && *r.IsDefault {
+			continue
+		}
+
+		conditions := []string{}
+		for _, c := range r.Conditions {
+			conditions = append(conditions, ruleCondition(c))
+		}
+
+		list = append(list, ListenerRule{
+			Priority:   *r.Priority,
+			Conditions: conditions,
+			Actions:    newListenerActions(r.Actions),
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		a, _ := strconv.Atoi(list[i].Priority)
+		b, _ := strconv.Atoi(list[j].Priority)
+		return a < b
+	})
+
+	return list, nil
+}
+
+func ruleCondition(c types.RuleCondition) string {
+	field := "None"
+	if c.Field != nil {
+		field = *c.Field
+	}
+
+	values := c.Values
+	switch {
+	case c.HostHeaderConfig != nil:
+		values = c.HostHeaderConfig.Values
+	case c.PathPatternConfig != nil:
+		values = c.PathPatternConfig.Values
+	case c.HttpRequestMethodConfig != nil:
+		values = c.HttpRequestMethodConfig.Values
+	case c.SourceIpConfig != nil:
+		values = c.SourceIpConfig.Values
+	case c.HttpHeaderConfig != nil && c.HttpHeaderConfig.HttpHeaderName != nil:
+		field = *c.HttpHeaderConfig.HttpHeaderName
+		values = c.HttpHeaderConfig.Values
+	case c.QueryStringConfig != nil:
+		values = []string{}
+		for _, q := range c.QueryStringConfig.Values {
+			k := ""
+			if q.Key != nil {
+				k = *q.Key + "="
+			}
+			values = append(values, k+*q.Value)
+		}
+	}
+
+	return field + "=" + strings.Join(values, ",")
+}
+
+func newListenerActions(actions []types.Action) []ListenerAction {
+	list := []ListenerAction{}
+	for _, a := range actions {
+		action := ListenerAction{
+			Type:   string(a.Type),
+			Detail: "None",
+		}
+
+		if a.ForwardConfig != nil {
+			for _, t := range a.ForwardConfig.TargetGroups {
+				action.TargetGroupArns = append(action.TargetGroupArns, *t.TargetGroupArn)
+			}
+		} else if a.TargetGroupArn != nil {
+			action.TargetGroupArns = []string{*a.TargetGroupArn}
+		}
+
+		if r := a.RedirectConfig; r != nil {
+			action.Detail = string(r.StatusCode) + " " + aws.ToString(r.Protocol) + "://" + aws.ToString(r.Host) + ":" + aws.ToString(r.Port) + aws.ToString(r.Path)
+		}
+
+		if f := a.FixedResponseConfig; f != nil && f.StatusCode != nil {
+			action.Detail = *f.StatusCode
+		}
+
+		list = append(list, action)
+	}
+
+	return list
+}
+
+// DescribeTargetGroups returns slice TargetGroup structure.
+func (c *ELBV2) DescribeTargetGroups(input *elbv2.DescribeTargetGroupsInput) ([]TargetGroup, error) {
+	list := []TargetGroup{}
+	paginator := elbv2.NewDescribeTargetGroupsPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe target groups: %v", err)
+		}
+
+		for _, t := range output.TargetGroups {
+			var port int32
+			if t.Port != nil {
+				port = *t.Port
+			}
+
+			list = append(list, TargetGroup{
+				TargetGroupArn: *t.TargetGroupArn,
+				Name:           *t.TargetGroupName,
+				Protocol:       string(t.Protocol),
+				Port:           port,
+				TargetType:     string(t.TargetType),
+			})
+		}
+	}
+
+	return list, nil
+}
+
+// DescribeTargetHealth returns slice Target structure of the target group.
+func (c *ELBV2) DescribeTargetHealth(input *elbv2.DescribeTargetHealthInput) ([]Target, error) {
+	output, err := c.Client.DescribeTargetHealth(context.TODO(), input)
+	if err != nil {
+		return nil, fmt.Errorf("describe target health: %v", err)
+	}
+
+	list := []Target{}
+	for _, t := range output.TargetHealthDescriptions {
+		target := Target{
+			Id:               *t.Target.Id,
+			AvailabilityZone: "None",
+			State:            "None",
+			Reason:           "None",
+		}
+
+		if t.Target.Port != nil {
+			target.Port = *t.Target.Port
+		}
+
+		if t.Target.AvailabilityZone != nil {
+			target.AvailabilityZone = *t.Target.AvailabilityZone
+		}
+
+		if t.TargetHealth != nil {
+			target.State = string(t.TargetHealth.State)
+			if len(t.TargetHealth.Reason) > 0 {
+				target.Reason = string(t.TargetHealth.Reason)
+			}
+		}
+
+		list = append(list, target)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Id < list[j].Id
+	})
+
+	return list, nil
+}
+
+// DescribeInstanceHealth returns slice Target structure of the classic load balancer.
+func (c *ELB) DescribeInstanceHealth(input *elb.DescribeInstanceHealthInput) ([]Target, error) {
+	output, err := c.Client.DescribeInstanceHealth(context.TODO(), input)
+	if err != nil {
+		return nil, fmt.Errorf("describe instance health: %v", err)
+	}
+
+	list := []Target{}
+	for _, s := range output.InstanceStates {
+		reason := "None"
+		if s.ReasonCode != nil && *s.ReasonCode != "N/A" {
+			reason = *s.ReasonCode
+		}
+
+		list = append(list, Target{
+			Id:               *s.InstanceId,
+			AvailabilityZone: "None",
+			State:            *s.State,
+			Reason:           reason,
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Id < list[j].Id
+	})
+
+	return list, nil
+}
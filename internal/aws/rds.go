@@ -8,8 +8,12 @@ import (
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/rds/auth"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
 )
 
 // RDS structure is rds client.
@@ -25,39 +29,100 @@ func NewRdsClient(profile, region string) *RDS {
 }
 
 // DBInstance structure is rds instance information.
+// MaintenanceWindow is the preferred weekly window in UTC (e.g. sun:18:00-sun:18:30).
 type DBInstance struct {
-	Name             string
-	DBInstanceClass  string
-	Engine           string
-	EngineVersion    string
-	Storage          string
-	StorageType      string
-	DBInstanceStatus string
+	Name              string
+	Arn               string
+	Cluster           string
+	DBInstanceClass   string
+	Engine            string
+	EngineVersion     string
+	Storage           string
+	StorageType       string
+	DBInstanceStatus  string
+	AvailabilityZone  string
+	Endpoint          string
+	IAMAuthEnabled    bool
+	ParameterGroup    string
+	ParameterStatus   string
+	MaintenanceWindow string
+	CACertificate     string
+	CertificateExpiry time.Time
+	SecurityGroups    []string
 }
 
 // DescribeDBInstances returns slice DBInstance structure.
 func (c *RDS) DescribeDBInstances(input *rds.DescribeDBInstancesInput) ([]DBInstance, error) {
-	ctx := context.TODO()
-	output, err := c.Client.DescribeDBInstances(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("describe db instances: %v", err)
-	}
+	list := []DBInstance{}
+	paginator := rds.NewDescribeDBInstancesPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe db instances: %v", err)
+		}
+
+		for _, i := range output.DBInstances {
+			groups := []string{}
+			for _, g := range i.VpcSecurityGroups {
+				groups = append(groups, *g.VpcSecurityGroupId)
+			}
+
+			endpoint := "None"
+			if i.Endpoint != nil && i.Endpoint.Address != nil {
+				endpoint = *i.Endpoint.Address + ":" + strconv.Itoa(int(*i.Endpoint.Port))
+			}
+
+			az := "None"
+			if i.AvailabilityZone != nil {
+				az = *i.AvailabilityZone
+			}
 
-	if len(output.DBInstances) == 0 {
-		return nil, fmt.Errorf("no resources")
+			cert := "None"
+			var expiry time.Time
+			if i.CACertificateIdentifier != nil {
+				cert = *i.CACertificateIdentifier
+			}
+			if i.CertificateDetails != nil && i.CertificateDetails.ValidTill != nil {
+				expiry = *i.CertificateDetails.ValidTill
+			}
+
+			cluster := "None"
+			if i.DBClusterIdentifier != nil {
+				cluster = *i.DBClusterIdentifier
+			}
+
+			group, status := "None", "None"
+			if len(i.DBParameterGroups) > 0 {
+				group = aws.ToString(i.DBParameterGroups[0].DBParameterGroupName)
+				status = aws.ToString(i.DBParameterGroups[0].ParameterApplyStatus)
+			}
+
+			list = append(list, DBInstance{
+				Name:              *i.DBInstanceIdentifier,
+				Arn:               aws.ToString(i.DBInstanceArn),
+				Cluster:           cluster,
+				DBInstanceClass:   *i.DBInstanceClass,
+				Engine:            *i.Engine,
+				EngineVersion:     *i.EngineVersion,
+				Storage:           strconv.Itoa(int(*i.AllocatedStorage)) + "GB",
+				StorageType:       *i.StorageType,
+				DBInstanceStatus:  *i.DBInstanceStatus,
+				AvailabilityZone:  az,
+				Endpoint:          endpoint,
+				IAMAuthEnabled:    aws.ToBool(i.IAMDatabaseAuthenticationEnabled),
+				ParameterGroup:    group,
+				ParameterStatus:   status,
+				MaintenanceWindow: aws.ToString(i.PreferredMaintenanceWindow),
+				CACertificate:     cert,
+				CertificateExpiry: expiry,
+				SecurityGroups:    groups,
+			})
+		}
 	}
 
-	list := []DBInstance{}
-	for _, i := range output.DBInstances {
-		list = append(list, DBInstance{
-			Name:             *i.DBInstanceIdentifier,
-			DBInstanceClass:  *i.DBInstanceClass,
-			Engine:           *i.Engine,
-			EngineVersion:    *i.EngineVersion,
-			Storage:          strconv.Itoa(int(*i.AllocatedStorage)) + "GB",
-			StorageType:      *i.StorageType,
-			DBInstanceStatus: *i.DBInstanceStatus,
-		})
+	if len(list) == 0 {
+		return nil, ErrNoResources
 	}
 
 	sort.Slice(list, func(i, j int) bool {
@@ -110,41 +175,134 @@ func (i *DBInstance) RdsTabString() string {
 	return strings.Join(fields, "\t")
 }
 
+// BuildAuthToken returns an IAM database authentication token of the user,
+// which is signed locally with the credentials of the client and valid for 15 minutes.
+// endpoint is host:port of the db instance.
+func (c *RDS) BuildAuthToken(endpoint, user string) (string, error) {
+	opts := c.Client.Options()
+
+	token, err := auth.BuildAuthToken(context.TODO(), endpoint, opts.Region, user, opts.Credentials)
+	if err != nil {
+		return "", fmt.Errorf("build auth token: %v", err)
+	}
+
+	return token, nil
+}
+
 // DBCluster structure is rds cluster information.
+// ServerlessV2 is the min - max ACU of serverless v2 scaling configuration.
 type DBCluster struct {
-	Name          string
-	EngineMode    string
-	EngineVersion string
-	Capacity      string
-	Status        string
+	Name              string
+	Arn               string
+	Engine            string
+	EngineMode        string
+	EngineVersion     string
+	Capacity          string
+	ServerlessV2      string
+	Status            string
+	Endpoint          string
+	ReaderEndpoint    string
+	Port              int32
+	ParameterGroup    string
+	MaintenanceWindow string
+	Members           []DBClusterMember
+}
+
+// DBClusterMember structure is rds cluster member information.
+type DBClusterMember struct {
+	Name            string
+	IsWriter        bool
+	PromotionTier   int32
+	ParameterStatus string
 }
 
 // DescribeDBClusters returns slice DBCluster structure.
 func (c *RDS) DescribeDBClusters(input *rds.DescribeDBClustersInput) ([]DBCluster, error) {
-	ctx := context.TODO()
-	output, err := c.Client.DescribeDBClusters(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("describe db clusters: %v", err)
-	}
+	list := []DBCluster{}
+	paginator := rds.NewDescribeDBClustersPaginator(c.Client, input)
 
-	if len(output.DBClusters) == 0 {
-		return nil, fmt.Errorf("no resources")
-	}
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe db clusters: %v", err)
+		}
 
-	list := []DBCluster{}
-	for _, i := range output.DBClusters {
-		var cap string = "None"
-		if i.Capacity != nil {
-			cap = strconv.Itoa(int(*i.Capacity))
+		for _, i := range output.DBClusters {
+			var cap string = "None"
+			if i.Capacity != nil {
+				cap = strconv.Itoa(int(*i.Capacity))
+			}
+
+			endpoint := "None"
+			if i.Endpoint != nil {
+				endpoint = *i.Endpoint
+			}
+
+			reader := "None"
+			if i.ReaderEndpoint != nil {
+				reader = *i.ReaderEndpoint
+			}
+
+			serverless := "None"
+			if s := i.ServerlessV2ScalingConfiguration; s != nil && s.MinCapacity != nil && s.MaxCapacity != nil {
+				serverless = strconv.FormatFloat(*s.MinCapacity, 'f', -1, 64) + " - " + strconv.FormatFloat(*s.MaxCapacity, 'f', -1, 64) + " ACU"
+			}
+
+			var port int32
+			if i.Port != nil {
+				port = *i.Port
+			}
+
+			members := []DBClusterMember{}
+			for _, m := range i.DBClusterMembers {
+				member := DBClusterMember{
+					Name:            *m.DBInstanceIdentifier,
+					ParameterStatus: "None",
+				}
+				if m.DBClusterParameterGroupStatus != nil {
+					member.ParameterStatus = *m.DBClusterParameterGroupStatus
+				}
+				if m.IsClusterWriter != nil {
+					member.IsWriter = *m.IsClusterWriter
+				}
+				if m.PromotionTier != nil {
+					member.PromotionTier = *m.PromotionTier
+				}
+				members = append(members, member)
+			}
+
+			// writer first, then readers in failover order
+			sort.Slice(members, func(i, j int) bool {
+				if members[i].IsWriter != members[j].IsWriter {
+					return members[i].IsWriter
+				}
+				if members[i].PromotionTier != members[j].PromotionTier {
+					return members[i].PromotionTier < members[j].PromotionTier
+				}
+				return members[i].Name < members[j].Name
+			})
+
+			list = append(list, DBCluster{
+				Name:              *i.DBClusterIdentifier,
+				Arn:               aws.ToString(i.DBClusterArn),
+				Engine:            *i.Engine,
+				EngineMode:        *i.EngineMode,
+				EngineVersion:     *i.EngineVersion,
+				Capacity:          cap,
+				ServerlessV2:      serverless,
+				Status:            *i.Status,
+				Endpoint:          endpoint,
+				ReaderEndpoint:    reader,
+				Port:              port,
+				ParameterGroup:    aws.ToString(i.DBClusterParameterGroup),
+				MaintenanceWindow: aws.ToString(i.PreferredMaintenanceWindow),
+				Members:           members,
+			})
 		}
+	}
 
-		list = append(list, DBCluster{
-			Name:          *i.DBClusterIdentifier,
-			EngineMode:    *i.EngineMode,
-			EngineVersion: *i.EngineVersion,
-			Capacity:      cap,
-			Status:        string(i.ActivityStreamStatus),
-		})
+	if len(list) == 0 {
+		return nil, ErrNoResources
 	}
 
 	sort.Slice(list, func(i, j int) bool {
@@ -194,34 +352,52 @@ func (i *DBCluster) RdsClusterTabString() string {
 }
 
 // DBClusterEndpoint structure is rds cluster endpoint information.
+// CustomEndpointType is READER or ANY for custom endpoints, otherwise None.
 type DBClusterEndpoint struct {
-	Endpoint     string
-	EndpointType string
-	Status       string
+	DBClusterIdentifier string
+	Endpoint            string
+	EndpointType        string
+	CustomEndpointType  string
+	StaticMembers       []string
+	Status              string
 }
 
-// DescribeDBClusterEndpoints returns slice DBInstance structure.
+// DescribeDBClusterEndpoints returns slice DBClusterEndpoint structure.
 func (c *RDS) DescribeDBClusterEndpoints(input *rds.DescribeDBClusterEndpointsInput) ([]DBClusterEndpoint, error) {
-	ctx := context.TODO()
-	output, err := c.Client.DescribeDBClusterEndpoints(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("describe db cluster endpoints: %v", err)
-	}
+	list := []DBClusterEndpoint{}
+	paginator := rds.NewDescribeDBClusterEndpointsPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("describe db cluster endpoints: %v", err)
+		}
 
-	if len(output.DBClusterEndpoints) == 0 {
-		return nil, fmt.Errorf("no resources")
+		for _, i := range output.DBClusterEndpoints {
+			custom := "None"
+			if i.CustomEndpointType != nil {
+				custom = *i.CustomEndpointType
+			}
+
+			list = append(list, DBClusterEndpoint{
+				DBClusterIdentifier: *i.DBClusterIdentifier,
+				Endpoint:            *i.Endpoint,
+				EndpointType:        *i.EndpointType,
+				CustomEndpointType:  custom,
+				StaticMembers:       i.StaticMembers,
+				Status:              *i.Status,
+			})
+		}
 	}
 
-	list := []DBClusterEndpoint{}
-	for _, i := range output.DBClusterEndpoints {
-		list = append(list, DBClusterEndpoint{
-			Endpoint:     *i.Endpoint,
-			EndpointType: *i.EndpointType,
-			Status:       *i.Status,
-		})
+	if len(list) == 0 {
+		return nil, ErrNoResources
 	}
 
 	sort.Slice(list, func(i, j int) bool {
+		if list[i].DBClusterIdentifier != list[j].DBClusterIdentifier {
+			return list[i].DBClusterIdentifier < list[j].DBClusterIdentifier
+		}
 		return list[i].Endpoint < list[j].Endpoint
 	})
 
@@ -231,6 +407,7 @@ func (c *RDS) DescribeDBClusterEndpoints(input *rds.DescribeDBClusterEndpointsIn
 func PrintDBClusterEndpoints(wrt io.Writer, resources []DBClusterEndpoint) error {
 	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
 	header := []string{
+		"Cluster",
 		"Endpoint",
 		"EndpointType",
 		"Status",
@@ -255,6 +432,7 @@ func PrintDBClusterEndpoints(wrt io.Writer, resources []DBClusterEndpoint) error
 
 func (i *DBClusterEndpoint) RdsClusterEndpointTabString() string {
 	fields := []string{
+		i.DBClusterIdentifier,
 		i.Endpoint,
 		i.EndpointType,
 		i.Status,
@@ -268,10 +446,32 @@ type ExportTasks struct {
 	ExportTaskIdentifier string
 	Source               string
 	Status               string
+	PercentProgress      int32
+	ExtractedDataInGB    int32
+	S3Bucket             string
+	S3Prefix             string
+	FailureCause         string
 	TaskStartTime        string
 	TaskEndTime          string
 }
 
+// IsDone returns true when the export task is finished.
+func (i *ExportTasks) IsDone() bool {
+	switch i.Status {
+	case "COMPLETE", "CANCELED", "FAILED":
+		return true
+	}
+	return false
+}
+
+// OutputPrefix returns the S3 prefix which the export task writes the files to.
+func (i *ExportTasks) OutputPrefix() string {
+	if len(i.S3Prefix) == 0 {
+		return i.ExportTaskIdentifier + "/"
+	}
+	return strings.TrimSuffix(i.S3Prefix, "/") + "/" + i.ExportTaskIdentifier + "/"
+}
+
 // DescribeExportTasks returns slice ExportTasks structure.
 func (c *RDS) DescribeExportTasks(input *rds.DescribeExportTasksInput) ([]ExportTasks, error) {
 	list := []ExportTasks{}
@@ -284,26 +484,7 @@ func (c *RDS) DescribeExportTasks(input *rds.DescribeExportTasksInput) ([]Export
 		}
 
 		for _, i := range output.ExportTasks {
-			startTime := "None"
-			if i.TaskStartTime != nil {
-				startTime = i.TaskStartTime.String()
-			}
-
-			endTime := "None"
-			if i.TaskEndTime != nil {
-				endTime = i.TaskEndTime.String()
-			}
-
-			split := strings.Split(*i.SourceArn, ":")
-			source := split[len(split)-1]
-
-			list = append(list, ExportTasks{
-				ExportTaskIdentifier: *i.ExportTaskIdentifier,
-				Source:               source,
-				Status:               string(*i.Status),
-				TaskStartTime:        startTime,
-				TaskEndTime:          endTime,
-			})
+			list = append(list, newExportTask(i))
 		}
 	}
 
@@ -314,12 +495,78 @@ func (c *RDS) DescribeExportTasks(input *rds.DescribeExportTasksInput) ([]Export
 	return list, nil
 }
 
+func newExportTask(i types.ExportTask) ExportTasks {
+	startTime := "None"
+	if i.TaskStartTime != nil {
+		startTime = i.TaskStartTime.String()
+	}
+
+	endTime := "None"
+	if i.TaskEndTime != nil {
+		endTime = i.TaskEndTime.String()
+	}
+
+	split := strings.Split(*i.SourceArn, ":")
+	source := split[len(split)-1]
+
+	cause := "None"
+	if i.FailureCause != nil {
+		cause = *i.FailureCause
+	}
+
+	return ExportTasks{
+		ExportTaskIdentifier: *i.ExportTaskIdentifier,
+		Source:               source,
+		Status:               *i.Status,
+		PercentProgress:      aws.ToInt32(i.PercentProgress),
+		ExtractedDataInGB:    aws.ToInt32(i.TotalExtractedDataInGB),
+		S3Bucket:             aws.ToString(i.S3Bucket),
+		S3Prefix:             aws.ToString(i.S3Prefix),
+		FailureCause:         cause,
+		TaskStartTime:        startTime,
+		TaskEndTime:          endTime,
+	}
+}
+
+// StartExportTask starts exporting the snapshot to S3 and returns the task.
+func (c *RDS) StartExportTask(input *rds.StartExportTaskInput) (ExportTasks, error) {
+	output, err := c.Client.StartExportTask(context.TODO(), input)
+	if err != nil {
+		return ExportTasks{}, fmt.Errorf("start export task: %v", err)
+	}
+
+	return newExportTask(types.ExportTask{
+		ExportTaskIdentifier:   output.ExportTaskIdentifier,
+		SourceArn:              output.SourceArn,
+		Status:                 output.Status,
+		S3Bucket:               output.S3Bucket,
+		S3Prefix:               output.S3Prefix,
+		TaskStartTime:          output.TaskStartTime,
+		TaskEndTime:            output.TaskEndTime,
+		PercentProgress:        output.PercentProgress,
+		FailureCause:           output.FailureCause,
+		TotalExtractedDataInGB: output.TotalExtractedDataInGB,
+	}), nil
+}
+
+// CancelExportTask cancels the export task.
+// Data which has already been written to S3 is not removed.
+func (c *RDS) CancelExportTask(input *rds.CancelExportTaskInput) error {
+	if _, err := c.Client.CancelExportTask(context.TODO(), input); err != nil {
+		return fmt.Errorf("cancel export task: %v", err)
+	}
+
+	return nil
+}
+
 func PrintExportTasks(wrt io.Writer, resources []ExportTasks) error {
 	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
 	header := []string{
 		"ExportTaskIdentifier",
 		"SourceArn",
 		"Status",
+		"Progress",
+		"Extracted",
 		"TaskStartTime",
 		"TaskEndTime",
 	}
@@ -346,6 +593,8 @@ func (i *ExportTasks) ExportTasksTabString() string {
 		i.ExportTaskIdentifier,
 		i.Source,
 		i.Status,
+		strconv.Itoa(int(i.PercentProgress)) + "%",
+		strconv.Itoa(int(i.ExtractedDataInGB)) + "GB",
 		i.TaskStartTime,
 		i.TaskEndTime,
 	}
@@ -39,61 +39,65 @@ type Records []Record
 // ListHostedZones return Records
 // input route53.ListHostedZonesInput
 func (c *Route53) ListHostedZones(input *route53.ListHostedZonesInput) (Records, error) {
-	zones, err := c.Client.ListHostedZones(context.TODO(), input)
-	if err != nil {
-		return nil, fmt.Errorf("list hostedzones: %v", err)
-	}
-
 	list := Records{}
-	for _, h := range zones.HostedZones {
-		s := strings.Split(*h.Id, "/")
-		zoneid := s[2]
+	zones := route53.NewListHostedZonesPaginator(c.Client, input)
 
-		rinput := &route53.ListResourceRecordSetsInput{
-			HostedZoneId: h.Id,
+	for zones.HasMorePages() {
+		page, err := zones.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("list hostedzones: %v", err)
 		}
 
-		paginator := route53.NewListResourceRecordSetsPaginator(c.Client, rinput)
+		for _, h := range page.HostedZones {
+			s := strings.Split(*h.Id, "/")
+			zoneid := s[2]
 
-		for paginator.HasMorePages() {
-			output, err := paginator.NextPage(context.Background())
-			if err != nil {
-				return nil, fmt.Errorf("list resource record sets: %v", err)
+			rinput := &route53.ListResourceRecordSetsInput{
+				HostedZoneId: h.Id,
 			}
 
-			for _, r := range output.ResourceRecordSets {
+			paginator := route53.NewListResourceRecordSetsPaginator(c.Client, rinput)
 
-				if r.TTL == nil {
-					r.TTL = aws.Int64(0000)
+			for paginator.HasMorePages() {
+				output, err := paginator.NextPage(context.Background())
+				if err != nil {
+					return nil, fmt.Errorf("list resource record sets: %v", err)
 				}
-				ttl := strconv.FormatInt(*r.TTL, 10)
 
-				var value string
-				if r.AliasTarget == nil {
-					var values []string
+				for _, r := range output.ResourceRecordSets {
 
-					for _, rr := range r.ResourceRecords {
-						values = append(values, *rr.Value)
+					if r.TTL == nil {
+						r.TTL = aws.Int64(0000)
 					}
+					ttl := strconv.FormatInt(*r.TTL, 10)
 
-					value = strings.Join(values[:], ",")
-				} else if r.ResourceRecords == nil {
-					value = *r.AliasTarget.DNSName
-				}
+					var value string
+					if r.AliasTarget == nil {
+						var values []string
+
+						for _, rr := range r.ResourceRecords {
+							values = append(values, *rr.Value)
+						}
 
-				list = append(list, Record{
-					ZoneId:      zoneid,
-					DomainName:  *r.Name,
-					Type:        string(r.Type),
-					TTL:         ttl,
-					DomainValue: value,
-				})
+						value = strings.Join(values[:], ",")
+					} else if r.ResourceRecords == nil {
+						value = *r.AliasTarget.DNSName
+					}
+
+					list = append(list, Record{
+						ZoneId:      zoneid,
+						DomainName:  *r.Name,
+						Type:        string(r.Type),
+						TTL:         ttl,
+						DomainValue: value,
+					})
+				}
 			}
 		}
 	}
 
 	if len(list) == 0 {
-		return nil, fmt.Errorf("no resources")
+		return nil, ErrNoResources
 	}
 
 	return list, nil
@@ -49,7 +49,7 @@ func (c *S3) ListBuckets(input *s3.ListBucketsInput) ([]string, error) {
 	}
 
 	if len(buckets) == 0 {
-		return nil, fmt.Errorf("no resources")
+		return nil, ErrNoResources
 	}
 
 	return buckets, nil
@@ -58,24 +58,28 @@ func (c *S3) ListBuckets(input *s3.ListBucketsInput) ([]string, error) {
 // ListObjects return Objects
 // input s3.ListObjectsV2Input
 func (c *S3) ListObjects(input *s3.ListObjectsV2Input) (Objects, error) {
-	output, err := c.Client.ListObjectsV2(context.TODO(), input)
-	if err != nil {
-		return nil, fmt.Errorf("list objects: %v", err)
-	}
-
 	list := Objects{}
-	for _, l := range output.Contents {
+	paginator := s3.NewListObjectsV2Paginator(c.Client, input)
 
-		size := strconv.FormatInt(*l.Size, 10)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("list objects: %v", err)
+		}
 
-		list = append(list, Object{
-			Key:          *l.Key,
-			Size:         size,
-			LastModified: l.LastModified.String(),
-		})
+		for _, l := range output.Contents {
+
+			size := strconv.FormatInt(*l.Size, 10)
+
+			list = append(list, Object{
+				Key:          *l.Key,
+				Size:         size,
+				LastModified: l.LastModified.String(),
+			})
+		}
 	}
 	if len(list) == 0 {
-		return nil, fmt.Errorf("no resources")
+		return nil, ErrNoResources
 	}
 
 	sort.Slice(list, func(i, j int) bool {
@@ -0,0 +1,325 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/sfuruya0612/snatch/internal/mapping"
+)
+
+// SecurityGroup structure is security group information.
+type SecurityGroup struct {
+	GroupId     string
+	GroupName   string
+	VpcId       string
+	Description string
+	Inbound     []SecurityGroupRule
+	Outbound    []SecurityGroupRule
+}
+
+// SecurityGroupRule structure is a flattened security group rule.
+// FromPort and ToPort are -1 when the rule allows all ports.
+type SecurityGroupRule struct {
+	Protocol    string
+	FromPort    int32
+	ToPort      int32
+	Source      string
+	Description string
+}
+
+// DescribeSecurityGroups returns slice SecurityGroup structure.
+// Referenced groups are shown with their name when they are included in the result.
+func (c *EC2) DescribeSecurityGroups(input *ec2.DescribeSecurityGroupsInput) ([]SecurityGroup, error) {
+	list := []SecurityGroup{}
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("describe security groups: %v", err)
+		}
+
+		for _, g := range output.SecurityGroups {
+			vpc := "None"
+			if g.VpcId != nil {
+				vpc = *g.VpcId
+			}
+
+			list = append(list, SecurityGroup{
+				GroupId:     *g.GroupId,
+				GroupName:   *g.GroupName,
+				VpcId:       vpc,
+				Description: *g.Description,
+				Inbound:     flattenPermissions(g.IpPermissions),
+				Outbound:    flattenPermissions(g.IpPermissionsEgress),
+			})
+		}
+	}
+
+	names := map[string]string{}
+	for _, g := range list {
+		names[g.GroupId] = g.GroupName
+	}
+
+	for _, g := range list {
+		for _, rules := range [][]SecurityGroupRule{g.Inbound, g.Outbound} {
+			for i := range rules {
+				if n, ok := names[rules[i].Source]; ok {
+					rules[i].Source = rules[i].Source + " (" + n + ")"
+				}
+			}
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].GroupName < list[j].GroupName
+	})
+
+	return list, nil
+}
+
+// flattenPermissions returns one SecurityGroupRule per source of each permission.
+func flattenPermissions(perms []types.IpPermission) []SecurityGroupRule {
+	rules := []SecurityGroupRule{}
+	for _, p := range perms {
+		protocol := *p.IpProtocol
+		if protocol == "-1" {
+			protocol = "all"
+		}
+
+		from, to := int32(-1), int32(-1)
+		if p.FromPort != nil && p.ToPort != nil && protocol != "all" {
+			from, to = *p.FromPort, *p.ToPort
+		}
+
+		add := func(source string, description *string) {
+			desc := "None"
+			if description != nil {
+				desc = *description
+			}
+
+			rules = append(rules, SecurityGroupRule{
+				Protocol:    protocol,
+				FromPort:    from,
+				ToPort:      to,
+				Source:      source,
+				Description: desc,
+			})
+		}
+
+		for _, r := range p.IpRanges {
+			add(*r.CidrIp, r.Description)
+		}
+		for _, r := range p.Ipv6Ranges {
+			add(*r.CidrIpv6, r.Description)
+		}
+		for _, r := range p.PrefixListIds {
+			add(*r.PrefixListId, r.Description)
+		}
+		for _, r := range p.UserIdGroupPairs {
+			add(*r.GroupId, r.Description)
+		}
+	}
+
+	return rules
+}
+
+// SecurityGroupFinding structure is a world-open rule found by AuditSecurityGroups.
+type SecurityGroupFinding struct {
+	GroupId    string
+	GroupName  string
+	Rule       SecurityGroupRule
+	Exposed    []string
+	AttachedTo []string
+}
+
+// AuditSecurityGroups returns inbound rules open to 0.0.0.0/0 or ::/0 that cover a sensitive port.
+// With all, every world-open rule is returned.
+// attachments maps group id to the resources the group is attached to.
+func AuditSecurityGroups(groups []SecurityGroup, attachments map[string][]string, all bool) []SecurityGroupFinding {
+	ports := []int32{}
+	for p := range mapping.SensitivePorts {
+		ports = append(ports, p)
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i] < ports[j]
+	})
+
+	findings := []SecurityGroupFinding{}
+	for _, g := range groups {
+		for _, r := range g.Inbound {
+			if r.Source != "0.0.0.0/0" && r.Source != "::/0" {
+				continue
+			}
+
+			exposed := []string{}
+			if r.Protocol == "all" || r.Protocol == "tcp" || r.Protocol == "udp" || r.Protocol == "6" || r.Protocol == "17" {
+				for _, p := range ports {
+					if r.FromPort == -1 || (r.FromPort <= p && p <= r.ToPort) {
+						exposed = append(exposed, mapping.SensitivePorts[p]+"("+strconv.Itoa(int(p))+")")
+					}
+				}
+			}
+
+			if len(exposed) == 0 && !all {
+				continue
+			}
+
+			findings = append(findings, SecurityGroupFinding{
+				GroupId:    g.GroupId,
+				GroupName:  g.GroupName,
+				Rule:       r,
+				Exposed:    exposed,
+				AttachedTo: attachments[g.GroupId],
+			})
+		}
+	}
+
+	return findings
+}
+
+func PrintSecurityGroups(wrt io.Writer, resources []SecurityGroup) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"GroupId",
+		"GroupName",
+		"VpcId",
+		"Direction",
+		"Protocol",
+		"Ports",
+		"Source",
+		"Description",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		for _, rule := range r.Inbound {
+			if _, err := fmt.Fprintln(w, strings.Join([]string{r.GroupId, r.GroupName, r.VpcId, "inbound", rule.RuleTabString()}, "\t")); err != nil {
+				return fmt.Errorf("resources join: %v", err)
+			}
+		}
+		for _, rule := range r.Outbound {
+			if _, err := fmt.Fprintln(w, strings.Join([]string{r.GroupId, r.GroupName, r.VpcId, "outbound", rule.RuleTabString()}, "\t")); err != nil {
+				return fmt.Errorf("resources join: %v", err)
+			}
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func PrintUnusedSecurityGroups(wrt io.Writer, resources []SecurityGroup) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"GroupId",
+		"GroupName",
+		"VpcId",
+		"Description",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, strings.Join([]string{r.GroupId, r.GroupName, r.VpcId, r.Description}, "\t")); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func PrintSecurityGroupFindings(wrt io.Writer, resources []SecurityGroupFinding) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"GroupId",
+		"GroupName",
+		"Protocol",
+		"Ports",
+		"Source",
+		"Description",
+		"Exposed",
+		"AttachedTo",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.FindingTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (f *SecurityGroupFinding) FindingTabString() string {
+	exposed := "None"
+	if len(f.Exposed) > 0 {
+		exposed = strings.Join(f.Exposed, ",")
+	}
+
+	attached := "None"
+	if len(f.AttachedTo) > 0 {
+		attached = strings.Join(f.AttachedTo, ",")
+	}
+
+	fields := []string{
+		f.GroupId,
+		f.GroupName,
+		f.Rule.RuleTabString(),
+		exposed,
+		attached,
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// Ports returns port range of the rule as string.
+func (r *SecurityGroupRule) Ports() string {
+	switch {
+	case r.FromPort == -1 && r.ToPort == -1:
+		return "all"
+	case r.FromPort == r.ToPort:
+		return strconv.Itoa(int(r.FromPort))
+	default:
+		return strconv.Itoa(int(r.FromPort)) + "-" + strconv.Itoa(int(r.ToPort))
+	}
+}
+
+func (r *SecurityGroupRule) RuleTabString() string {
+	fields := []string{
+		r.Protocol,
+		r.Ports(),
+		r.Source,
+		r.Description,
+	}
+
+	return strings.Join(fields, "\t")
+}
@@ -0,0 +1,50 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAuditSecurityGroups(t *testing.T) {
+	groups := []SecurityGroup{
+		{
+			GroupId:   "sg-1",
+			GroupName: "web",
+			Inbound: []SecurityGroupRule{
+				{Protocol: "tcp", FromPort: 443, ToPort: 443, Source: "0.0.0.0/0"},
+				{Protocol: "tcp", FromPort: 22, ToPort: 22, Source: "::/0"},
+				{Protocol: "tcp", FromPort: 3306, ToPort: 3306, Source: "10.0.0.0/16"},
+			},
+		},
+		{
+			GroupId:   "sg-2",
+			GroupName: "debug",
+			Inbound: []SecurityGroupRule{
+				{Protocol: "tcp", FromPort: 3300, ToPort: 3400, Source: "0.0.0.0/0"},
+				{Protocol: "icmp", FromPort: -1, ToPort: -1, Source: "0.0.0.0/0"},
+			},
+		},
+	}
+	attachments := map[string][]string{"sg-1": {"ec2:web-1(i-1)"}}
+
+	findings := AuditSecurityGroups(groups, attachments, false)
+	if len(findings) != 2 {
+		t.Fatalf("Findings length should be 2, but got %v", findings)
+	}
+
+	if findings[0].GroupId != "sg-1" || !reflect.DeepEqual(findings[0].Exposed, []string{"SSH(22)"}) {
+		t.Errorf("sg-1 should expose SSH(22), but got %v", findings[0])
+	}
+
+	if !reflect.DeepEqual(findings[0].AttachedTo, []string{"ec2:web-1(i-1)"}) {
+		t.Errorf("sg-1 should be attached to web-1, but got %v", findings[0].AttachedTo)
+	}
+
+	if !reflect.DeepEqual(findings[1].Exposed, []string{"MySQL(3306)", "RDP(3389)"}) {
+		t.Errorf("sg-2 should expose MySQL and RDP, but got %v", findings[1].Exposed)
+	}
+
+	if all := AuditSecurityGroups(groups, attachments, true); len(all) != 4 {
+		t.Errorf("Findings length with all should be 4, but got %v", len(all))
+	}
+}
@@ -2,11 +2,15 @@ package aws
 
 import (
 	"context"
+	"errors"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 )
 
+// ErrNoResources is returned by the wrappers when the result is empty.
+var ErrNoResources = errors.New("no resources")
+
 // GetSession returns aws.Config structure.
 // The received structure is passed to `NewFromConfig` function of each AWS service.
 func GetSession(profile string, region string) aws.Config {
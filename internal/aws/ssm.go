@@ -1,9 +1,13 @@
 package aws
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -11,6 +15,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/sfuruya0612/snatch/internal/util"
+	"gopkg.in/yaml.v2"
 )
 
 // SSM client struct
@@ -62,6 +68,7 @@ type CmdLogs []CmdLog
 // Parameter parameter store struct
 type Parameter struct {
 	Name        string
+	Type        string
 	Value       string
 	Description string
 }
@@ -69,6 +76,19 @@ type Parameter struct {
 // Parameters Parameter struct slice
 type Parameters []Parameter
 
+// ParameterVersion parameter store history struct
+type ParameterVersion struct {
+	Version          string
+	Type             string
+	Value            string
+	LastModifiedUser string
+	LastModifiedDate string
+	Labels           string
+}
+
+// ParameterVersions ParameterVersion struct slice
+type ParameterVersions []ParameterVersion
+
 // DescribeInstanceInformation return []string (ssm.DescribeInstanceInformationOutput.InstanceId)
 // input ssm.DescribeInstanceInformationInput
 func (c *SSM) DescribeInstanceInformation(input *ssm.DescribeInstanceInformationInput) ([]string, error) {
@@ -89,6 +109,26 @@ func (c *SSM) DescribeInstanceInformation(input *ssm.DescribeInstanceInformation
 	return ids, nil
 }
 
+// DescribeInstancePingStatus return map[string]string (InstanceId: PingStatus)
+// input ssm.DescribeInstanceInformationInput
+func (c *SSM) DescribeInstancePingStatus(input *ssm.DescribeInstanceInformationInput) (map[string]string, error) {
+	status := map[string]string{}
+	paginator := ssm.NewDescribeInstanceInformationPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("describe instance information: %v", err)
+		}
+
+		for _, i := range page.InstanceInformationList {
+			status[*i.InstanceId] = string(i.PingStatus)
+		}
+	}
+
+	return status, nil
+}
+
 // CreateStartSession return ssm.StartSessionOutput, string ()
 // input ssm.DescribeInstanceInformationInput
 func (c *SSM) StartSession(input *ssm.StartSessionInput) (*ssm.StartSessionOutput, error) {
@@ -207,33 +247,160 @@ func (c *SSM) DescribeParameters(input *ssm.DescribeParametersInput) ([]types.Pa
 
 // GetParameter return Parameters
 // input []*ssm.ParameterMetadata
-func (c *SSM) GetParameter(params []types.ParameterMetadata) (Parameters, error) {
+// Values are fetched by GetParameters in batches of 10 names.
+func (c *SSM) GetParameter(params []types.ParameterMetadata, decrypt bool) (Parameters, error) {
 	list := Parameters{}
-	for _, p := range params {
-		input := &ssm.GetParameterInput{
-			Name: aws.String(*p.Name),
+	for i := 0; i < len(params); i += 10 {
+		batch := params[i:min(i+10, len(params))]
+
+		names := []string{}
+		for _, p := range batch {
+			names = append(names, *p.Name)
 		}
 
-		output, err := c.Client.GetParameter(context.TODO(), input)
+		values, err := c.GetParameters(&ssm.GetParametersInput{
+			Names:          names,
+			WithDecryption: aws.Bool(decrypt),
+		})
 		if err != nil {
-			return nil, fmt.Errorf("get parameter: %v", err)
+			return nil, err
 		}
 
-		description := "None"
-		if p.Description != nil {
-			description = *p.Description
+		for _, p := range batch {
+			description := "None"
+			if p.Description != nil {
+				description = *p.Description
+			}
+
+			for _, v := range values {
+				if v.Name != *p.Name {
+					continue
+				}
+
+				list = append(list, Parameter{
+					Name:        *p.Name,
+					Type:        string(p.Type),
+					Value:       v.Value,
+					Description: description,
+				})
+			}
 		}
+	}
+
+	return list, nil
+}
+
+// GetParametersByPath return Parameters
+// input ssm.GetParametersByPathInput
+func (c *SSM) GetParametersByPath(input *ssm.GetParametersByPathInput) (Parameters, error) {
+	list := Parameters{}
+	paginator := ssm.NewGetParametersByPathPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("get parameters by path: %v", err)
+		}
+
+		for _, p := range page.Parameters {
+			list = append(list, Parameter{
+				Name:        *p.Name,
+				Type:        string(p.Type),
+				Value:       *p.Value,
+				Description: "None",
+			})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
 
+	return list, nil
+}
+
+// GetParameters return Parameters
+// input ssm.GetParametersInput (up to 10 names)
+func (c *SSM) GetParameters(input *ssm.GetParametersInput) (Parameters, error) {
+	output, err := c.Client.GetParameters(context.TODO(), input)
+	if err != nil {
+		return nil, fmt.Errorf("get parameters: %v", err)
+	}
+
+	list := Parameters{}
+	for _, p := range output.Parameters {
 		list = append(list, Parameter{
 			Name:        *p.Name,
-			Value:       *output.Parameter.Value,
-			Description: description,
+			Type:        string(p.Type),
+			Value:       *p.Value,
+			Description: "None",
 		})
 	}
 
 	return list, nil
 }
 
+// GetParameterHistory return ParameterVersions (oldest first)
+// input ssm.GetParameterHistoryInput
+func (c *SSM) GetParameterHistory(input *ssm.GetParameterHistoryInput) (ParameterVersions, error) {
+	list := ParameterVersions{}
+	paginator := ssm.NewGetParameterHistoryPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("get parameter history: %v", err)
+		}
+
+		for _, p := range page.Parameters {
+			user := "None"
+			if p.LastModifiedUser != nil {
+				user = *p.LastModifiedUser
+			}
+
+			labels := "None"
+			if len(p.Labels) > 0 {
+				labels = strings.Join(p.Labels, ",")
+			}
+
+			list = append(list, ParameterVersion{
+				Version:          strconv.FormatInt(p.Version, 10),
+				Type:             string(p.Type),
+				Value:            *p.Value,
+				LastModifiedUser: user,
+				LastModifiedDate: p.LastModifiedDate.String(),
+				Labels:           labels,
+			})
+		}
+	}
+
+	if len(list) == 0 {
+		return nil, ErrNoResources
+	}
+
+	return list, nil
+}
+
+// PutParameter return none (Only error)
+// input ssm.PutParameterInput
+func (c *SSM) PutParameter(input *ssm.PutParameterInput) error {
+	if _, err := c.Client.PutParameter(context.TODO(), input); err != nil {
+		return fmt.Errorf("put parameter %s: %v", *input.Name, err)
+	}
+
+	return nil
+}
+
+// DeleteParameter return none (Only error)
+// input ssm.DeleteParameterInput
+func (c *SSM) DeleteParameter(input *ssm.DeleteParameterInput) error {
+	if _, err := c.Client.DeleteParameter(context.TODO(), input); err != nil {
+		return fmt.Errorf("delete parameter %s: %v", *input.Name, err)
+	}
+
+	return nil
+}
+
 func PrintSessHist(wrt io.Writer, resources Sessions) error {
 	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
 	header := []string{
@@ -316,6 +483,7 @@ func PrintParameters(wrt io.Writer, resources Parameters) error {
 	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
 	header := []string{
 		"Name",
+		"Type",
 		"Value",
 		"Description",
 	}
@@ -340,9 +508,203 @@ func PrintParameters(wrt io.Writer, resources Parameters) error {
 func (i *Parameter) ParameterTabString() string {
 	fields := []string{
 		i.Name,
+		i.Type,
 		i.Value,
 		i.Description,
 	}
 
 	return strings.Join(fields, "\t")
 }
+
+// PrintParameterHistory prints each version with the value diff from the previous version.
+func PrintParameterHistory(wrt io.Writer, resources ParameterVersions) error {
+	prev := ""
+	for i, r := range resources {
+		if _, err := fmt.Fprintf(wrt, "\x1b[35mVersion:\x1b[0m %s \x1b[35mModifiedBy:\x1b[0m %s \x1b[35mDate:\x1b[0m %s \x1b[35mLabels:\x1b[0m %s\n", r.Version, r.LastModifiedUser, r.LastModifiedDate, r.Labels); err != nil {
+			return fmt.Errorf("%v", err)
+		}
+
+		lines := []string{}
+		if i == 0 {
+			for _, l := range strings.Split(r.Value, "\n") {
+				lines = append(lines, "+ "+l)
+			}
+		} else {
+			lines = util.DiffLines(prev, r.Value)
+		}
+
+		for _, l := range lines {
+			if _, err := fmt.Fprintln(wrt, l); err != nil {
+				return fmt.Errorf("%v", err)
+			}
+		}
+
+		prev = r.Value
+	}
+
+	return nil
+}
+
+// secureStringMask is shown in place of SecureString values.
+const secureStringMask = "********"
+
+// MaskSecureString replaces SecureString values with a fixed mask.
+func MaskSecureString(resources Parameters) {
+	for i := range resources {
+		if resources[i].Type == string(types.ParameterTypeSecureString) {
+			resources[i].Value = secureStringMask
+		}
+	}
+}
+
+// MaskSecureChanges returns a copy of changes with values of SecureString parameters masked.
+// ptypes is parameter type keyed by the change key.
+func MaskSecureChanges(changes []util.Change, ptypes map[string]string) []util.Change {
+	list := make([]util.Change, len(changes))
+	for i, c := range changes {
+		if ptypes[c.Key] == string(types.ParameterTypeSecureString) {
+			c.Old = secureStringMask
+			c.New = secureStringMask
+		}
+		list[i] = c
+	}
+
+	return list
+}
+
+// BuildParameterTree returns tree view of the parameter hierarchy under path.
+func BuildParameterTree(resources Parameters, path string) *util.Tree {
+	root := util.NewTree(path)
+	for _, r := range resources {
+		spl := strings.Split(ParameterKey(path, r.Name), "/")
+
+		node := root
+		for _, s := range spl[:len(spl)-1] {
+			node = node.Child(s)
+		}
+		node.Add(spl[len(spl)-1] + " = " + r.Value)
+	}
+
+	return root
+}
+
+// ParameterKey returns the parameter name relative to path.
+func ParameterKey(path, name string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(name, strings.TrimSuffix(path, "/")), "/")
+}
+
+// ParameterName returns the full parameter name of key under path.
+func ParameterName(path, key string) string {
+	return strings.TrimSuffix(path, "/") + "/" + strings.TrimPrefix(key, "/")
+}
+
+// ExportParameters writes parameters under path as env, json or yaml.
+// The env format has no hierarchy, so nested parameter names are rejected.
+func ExportParameters(wrt io.Writer, resources Parameters, path, format string) error {
+	values := map[string]string{}
+	for _, r := range resources {
+		key := ParameterKey(path, r.Name)
+		// env names are flat, so nested names could not be imported back
+		if format == "env" && strings.Contains(key, "/") {
+			return fmt.Errorf("nested parameter %s cannot be exported as env, use json or yaml", r.Name)
+		}
+		values[key] = r.Value
+	}
+
+	switch format {
+	case "env":
+		keys := []string{}
+		for k := range values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if _, err := fmt.Fprintf(wrt, "%s=%s\n", k, strconv.Quote(values[k])); err != nil {
+				return fmt.Errorf("write env: %v", err)
+			}
+		}
+	case "json":
+		b, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return fmt.Errorf("json marshal: %v", err)
+		}
+
+		if _, err := fmt.Fprintln(wrt, string(b)); err != nil {
+			return fmt.Errorf("write json: %v", err)
+		}
+	case "yaml":
+		b, err := yaml.Marshal(values)
+		if err != nil {
+			return fmt.Errorf("yaml marshal: %v", err)
+		}
+
+		if _, err := wrt.Write(b); err != nil {
+			return fmt.Errorf("write yaml: %v", err)
+		}
+	default:
+		return fmt.Errorf("unsupported format %s (env, json or yaml)", format)
+	}
+
+	return nil
+}
+
+// ImportParameters reads env, json or yaml and returns values keyed by full parameter name under path.
+func ImportParameters(r io.Reader, path, format string) (map[string]string, error) {
+	values := map[string]string{}
+
+	switch format {
+	case "env":
+		s := bufio.NewScanner(r)
+		for s.Scan() {
+			line := strings.TrimSpace(s.Text())
+			if len(line) == 0 || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			spl := strings.SplitN(strings.TrimPrefix(line, "export "), "=", 2)
+			if len(spl) != 2 {
+				return nil, fmt.Errorf("parse env line=%s", line)
+			}
+
+			v := spl[1]
+			if strings.HasPrefix(v, "\"") {
+				u, err := strconv.Unquote(v)
+				if err != nil {
+					return nil, fmt.Errorf("unquote %s: %v", spl[0], err)
+				}
+				v = u
+			} else if strings.HasPrefix(v, "'") && strings.HasSuffix(v, "'") && len(v) > 1 {
+				v = v[1 : len(v)-1]
+			}
+
+			values[strings.TrimSpace(spl[0])] = v
+		}
+
+		if err := s.Err(); err != nil {
+			return nil, fmt.Errorf("read env: %v", err)
+		}
+	case "json":
+		if err := json.NewDecoder(r).Decode(&values); err != nil {
+			return nil, fmt.Errorf("json decode: %v", err)
+		}
+	case "yaml":
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("read yaml: %v", err)
+		}
+
+		if err := yaml.Unmarshal(b, &values); err != nil {
+			return nil, fmt.Errorf("yaml unmarshal: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %s (env, json or yaml)", format)
+	}
+
+	params := map[string]string{}
+	for k, v := range values {
+		params[ParameterName(path, k)] = v
+	}
+
+	return params, nil
+}
@@ -0,0 +1,91 @@
+package aws
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sfuruya0612/snatch/internal/util"
+)
+
+func TestExportImportParameters(t *testing.T) {
+	params := Parameters{
+		{Name: "/app/prod/DB_URL", Value: "postgres://db:5432/app"},
+		{Name: "/app/prod/GREETING", Value: "hello \"world\"\nbye"},
+		{Name: "/app/prod/nested/KEY", Value: "v"},
+	}
+
+	for _, format := range []string{"json", "yaml"} {
+		var buf bytes.Buffer
+		if err := ExportParameters(&buf, params, "/app/prod", format); err != nil {
+			t.Fatalf("%s: export error should be nil, but got %v", format, err)
+		}
+
+		values, err := ImportParameters(&buf, "/app/stg/", format)
+		if err != nil {
+			t.Fatalf("%s: import error should be nil, but got %v", format, err)
+		}
+
+		if len(values) != len(params) {
+			t.Errorf("%s: values length should be %d, but got %d", format, len(params), len(values))
+		}
+
+		for _, p := range params {
+			name := "/app/stg/" + ParameterKey("/app/prod", p.Name)
+			if values[name] != p.Value {
+				t.Errorf("%s: %s should be %q, but got %q", format, name, p.Value, values[name])
+			}
+		}
+	}
+
+	flat := params[:2]
+
+	var buf bytes.Buffer
+	if err := ExportParameters(&buf, flat, "/app/prod", "env"); err != nil {
+		t.Fatalf("env: export error should be nil, but got %v", err)
+	}
+
+	values, err := ImportParameters(&buf, "/app/stg", "env")
+	if err != nil {
+		t.Fatalf("env: import error should be nil, but got %v", err)
+	}
+
+	if len(values) != len(flat) {
+		t.Errorf("env: values length should be %d, but got %d", len(flat), len(values))
+	}
+
+	for _, p := range flat {
+		name := "/app/stg/" + ParameterKey("/app/prod", p.Name)
+		if values[name] != p.Value {
+			t.Errorf("env: %s should be %q, but got %q", name, p.Value, values[name])
+		}
+	}
+
+	if err := ExportParameters(&bytes.Buffer{}, params, "/app/prod", "env"); err == nil {
+		t.Errorf("env: export of nested parameters should be error")
+	}
+}
+
+func TestMaskSecureChanges(t *testing.T) {
+	changes := []util.Change{
+		{Key: "/app/DB_PASSWORD", Type: util.Modified, Old: "old", New: "new"},
+		{Key: "/app/DB_HOST", Type: util.Added, New: "db.local"},
+	}
+	ptypes := map[string]string{
+		"/app/DB_PASSWORD": "SecureString",
+		"/app/DB_HOST":     "String",
+	}
+
+	masked := MaskSecureChanges(changes, ptypes)
+
+	if masked[0].Old != "********" || masked[0].New != "********" {
+		t.Errorf("SecureString should be masked, but got %v", masked[0])
+	}
+
+	if masked[1].New != "db.local" {
+		t.Errorf("String should not be masked, but got %v", masked[1])
+	}
+
+	if changes[0].New != "new" {
+		t.Errorf("changes should not be modified, but got %v", changes[0])
+	}
+}
@@ -0,0 +1,278 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+)
+
+// tagBatchSize is the maximum number of ARNs accepted by TagResources and UntagResources.
+const tagBatchSize = 20
+
+// Tagging structure is resource groups tagging api client.
+type Tagging struct {
+	Client *resourcegroupstaggingapi.Client
+}
+
+// NewTaggingClient returns Tagging struct initialized.
+func NewTaggingClient(profile, region string) *Tagging {
+	return &Tagging{
+		Client: resourcegroupstaggingapi.NewFromConfig(GetSession(profile, region)),
+	}
+}
+
+// TaggedResource structure is tagged resource information.
+type TaggedResource struct {
+	ARN          string
+	Service      string
+	ResourceType string
+	Tags         map[string]string
+}
+
+// GetResources returns slice TaggedResource structure.
+// Resources which have never been tagged are not returned by the API.
+func (c *Tagging) GetResources(input *resourcegroupstaggingapi.GetResourcesInput) ([]TaggedResource, error) {
+	list := []TaggedResource{}
+	paginator := resourcegroupstaggingapi.NewGetResourcesPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("get resources: %v", err)
+		}
+
+		for _, r := range output.ResourceTagMappingList {
+			service, rtype := parseArn(*r.ResourceARN)
+
+			tags := map[string]string{}
+			for _, t := range r.Tags {
+				tags[*t.Key] = *t.Value
+			}
+
+			list = append(list, TaggedResource{
+				ARN:          *r.ResourceARN,
+				Service:      service,
+				ResourceType: rtype,
+				Tags:         tags,
+			})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].ResourceType != list[j].ResourceType {
+			return list[i].ResourceType < list[j].ResourceType
+		}
+		return list[i].ARN < list[j].ARN
+	})
+
+	return list, nil
+}
+
+// parseArn returns the service and the resource type (e.g. "ec2:instance") of arn.
+func parseArn(arn string) (string, string) {
+	spl := strings.SplitN(arn, ":", 6)
+	if len(spl) != 6 {
+		return "None", "None"
+	}
+
+	service, resource := spl[2], spl[5]
+	if i := strings.IndexAny(resource, "/:"); i > 0 {
+		return service, service + ":" + resource[:i]
+	}
+
+	return service, service
+}
+
+// TagResources adds tags to the resources, 20 ARNs per request.
+// The returned map has the error message of each ARN which failed.
+func (c *Tagging) TagResources(arns []string, tags map[string]string) (map[string]string, error) {
+	failed := map[string]string{}
+	for i := 0; i < len(arns); i += tagBatchSize {
+		output, err := c.Client.TagResources(context.TODO(), &resourcegroupstaggingapi.TagResourcesInput{
+			ResourceARNList: arns[i:min(i+tagBatchSize, len(arns))],
+			Tags:            tags,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("tag resources: %v", err)
+		}
+
+		for arn, f := range output.FailedResourcesMap {
+			failed[arn] = string(f.ErrorCode)
+			if f.ErrorMessage != nil {
+				failed[arn] += ": " + *f.ErrorMessage
+			}
+		}
+	}
+
+	return failed, nil
+}
+
+// UntagResources removes tag keys from the resources, 20 ARNs per request.
+// The returned map has the error message of each ARN which failed.
+func (c *Tagging) UntagResources(arns []string, keys []string) (map[string]string, error) {
+	failed := map[string]string{}
+	for i := 0; i < len(arns); i += tagBatchSize {
+		output, err := c.Client.UntagResources(context.TODO(), &resourcegroupstaggingapi.UntagResourcesInput{
+			ResourceARNList: arns[i:min(i+tagBatchSize, len(arns))],
+			TagKeys:         keys,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("untag resources: %v", err)
+		}
+
+		for arn, f := range output.FailedResourcesMap {
+			failed[arn] = string(f.ErrorCode)
+			if f.ErrorMessage != nil {
+				failed[arn] += ": " + *f.ErrorMessage
+			}
+		}
+	}
+
+	return failed, nil
+}
+
+// TagCompliance structure is required tags missing from a resource.
+type TagCompliance struct {
+	Service      string
+	ResourceType string
+	ARN          string
+	Missing      []string
+}
+
+// CheckTagCompliance returns resources missing any of the required tags, sorted by service.
+// Tags with an empty value are regarded as missing.
+func CheckTagCompliance(resources []TaggedResource, required []string) []TagCompliance {
+	list := []TagCompliance{}
+	for _, r := range resources {
+		missing := []string{}
+		for _, k := range required {
+			if len(r.Tags[k]) == 0 {
+				missing = append(missing, k)
+			}
+		}
+
+		if len(missing) > 0 {
+			list = append(list, TagCompliance{
+				Service:      r.Service,
+				ResourceType: r.ResourceType,
+				ARN:          r.ARN,
+				Missing:      missing,
+			})
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Service < list[j].Service
+	})
+
+	return list
+}
+
+func PrintTaggedResources(wrt io.Writer, resources []TaggedResource) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"ResourceType",
+		"ARN",
+		"Tags",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.TaggedResourceTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (r *TaggedResource) TaggedResourceTabString() string {
+	tags := []string{}
+	for k, v := range r.Tags {
+		tags = append(tags, k+"="+v)
+	}
+	sort.Strings(tags)
+
+	fields := []string{
+		r.ResourceType,
+		r.ARN,
+		strings.Join(tags, ","),
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// PrintTagCompliance prints non compliant resources grouped by service, followed by
+// the number of non compliant resources and total (number of resources checked) of each service.
+func PrintTagCompliance(wrt io.Writer, resources []TagCompliance, total map[string]int) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"Service",
+		"ResourceType",
+		"ARN",
+		"MissingTags",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	count := map[string]int{}
+	for _, r := range resources {
+		count[r.Service]++
+		if _, err := fmt.Fprintln(w, r.TagComplianceTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	services := []string{}
+	for s := range total {
+		services = append(services, s)
+	}
+	sort.Strings(services)
+
+	w = tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	if _, err := fmt.Fprintln(w, "\nService\tNonCompliant\tTotal"); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, s := range services {
+		if _, err := fmt.Fprintln(w, s+"\t"+strconv.Itoa(count[s])+"\t"+strconv.Itoa(total[s])); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (c *TagCompliance) TagComplianceTabString() string {
+	fields := []string{
+		c.Service,
+		c.ResourceType,
+		c.ARN,
+		strings.Join(c.Missing, ","),
+	}
+
+	return strings.Join(fields, "\t")
+}
@@ -0,0 +1,33 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckTagCompliance(t *testing.T) {
+	arns := []string{
+		"arn:aws:s3:::bucket",
+		"arn:aws:ec2:ap-northeast-1:123456789012:instance/i-0123",
+		"arn:aws:rds:ap-northeast-1:123456789012:db:app",
+	}
+
+	resources := []TaggedResource{}
+	for i, a := range arns {
+		service, rtype := parseArn(a)
+		resources = append(resources, TaggedResource{ARN: a, Service: service, ResourceType: rtype, Tags: map[string]string{"Owner": "a"}})
+		if i == 1 {
+			resources[i].Tags["Env"] = "prod"
+		}
+	}
+
+	got := CheckTagCompliance(resources, []string{"Owner", "Env"})
+	want := []TagCompliance{
+		{Service: "rds", ResourceType: "rds:db", ARN: arns[2], Missing: []string{"Env"}},
+		{Service: "s3", ResourceType: "s3", ARN: arns[0], Missing: []string{"Env"}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckTagCompliance should be %v, but got %v", want, got)
+	}
+}
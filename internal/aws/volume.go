@@ -0,0 +1,400 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	"github.com/sfuruya0612/snatch/internal/mapping"
+	"github.com/sfuruya0612/snatch/internal/util"
+)
+
+// Volume structure is ebs volume information.
+type Volume struct {
+	VolumeId         string
+	Name             string
+	VolumeType       string
+	Size             int32
+	Iops             int32
+	Throughput       int32
+	State            string
+	Encrypted        bool
+	AvailabilityZone string
+	InstanceId       string
+	InstanceName     string
+	Device           string
+	CreateTime       time.Time
+}
+
+// DescribeVolumes returns slice Volume structure.
+func (c *EC2) DescribeVolumes(input *ec2.DescribeVolumesInput) ([]Volume, error) {
+	list := []Volume{}
+	paginator := ec2.NewDescribeVolumesPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("describe volumes: %v", err)
+		}
+
+		for _, v := range output.Volumes {
+			var iops, throughput int32
+			if v.Iops != nil {
+				iops = *v.Iops
+			}
+			if v.Throughput != nil {
+				throughput = *v.Throughput
+			}
+
+			instance, device := "None", "None"
+			if len(v.Attachments) > 0 {
+				instance = *v.Attachments[0].InstanceId
+				device = *v.Attachments[0].Device
+			}
+
+			list = append(list, Volume{
+				VolumeId:         *v.VolumeId,
+				Name:             nameTag(v.Tags),
+				VolumeType:       string(v.VolumeType),
+				Size:             *v.Size,
+				Iops:             iops,
+				Throughput:       throughput,
+				State:            string(v.State),
+				Encrypted:        *v.Encrypted,
+				AvailabilityZone: *v.AvailabilityZone,
+				InstanceId:       instance,
+				Device:           device,
+				CreateTime:       *v.CreateTime,
+			})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].VolumeId < list[j].VolumeId
+	})
+
+	return list, nil
+}
+
+func (v *Volume) VolumeTabString() string {
+	fields := []string{
+		v.VolumeId,
+		v.Device,
+		strconv.Itoa(int(v.Size)) + "GB",
+		v.VolumeType,
+		strconv.Itoa(int(v.Iops)),
+		strconv.FormatBool(v.Encrypted),
+		v.State,
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// MonthlyCost returns estimated monthly cost in USD of the volume.
+func (v *Volume) MonthlyCost() float64 {
+	p, ok := mapping.EbsPrices[v.VolumeType]
+	if !ok {
+		return 0
+	}
+
+	cost := float64(v.Size) * p.PerGB
+	if v.Iops > p.FreeIops {
+		cost += float64(v.Iops-p.FreeIops) * p.PerIops
+	}
+	if v.Throughput > p.FreeThroughput {
+		cost += float64(v.Throughput-p.FreeThroughput) * p.PerThroughput
+	}
+
+	return cost
+}
+
+// Gp3MonthlySavings returns estimated monthly savings in USD of migrating a gp2 volume to gp3
+// with the same baseline IOPS (3 IOPS/GB, 100 - 16000).
+func (v *Volume) Gp3MonthlySavings() float64 {
+	if v.VolumeType != "gp2" {
+		return 0
+	}
+
+	gp3 := Volume{
+		VolumeType: "gp3",
+		Size:       v.Size,
+		Iops:       min(max(v.Size*3, 100), 16000),
+	}
+
+	return v.MonthlyCost() - gp3.MonthlyCost()
+}
+
+func PrintVolumes(wrt io.Writer, resources []Volume) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"VolumeId",
+		"Name",
+		"Size",
+		"VolumeType",
+		"IOPS",
+		"Throughput",
+		"State",
+		"Attachment",
+		"Encrypted",
+		"AZ",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.VolumeListTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (v *Volume) VolumeListTabString() string {
+	attachment := "None"
+	if v.InstanceId != "None" {
+		attachment = v.InstanceName + "(" + v.InstanceId + "):" + v.Device
+	}
+
+	fields := []string{
+		v.VolumeId,
+		v.Name,
+		strconv.Itoa(int(v.Size)) + "GB",
+		v.VolumeType,
+		strconv.Itoa(int(v.Iops)),
+		strconv.Itoa(int(v.Throughput)),
+		v.State,
+		attachment,
+		strconv.FormatBool(v.Encrypted),
+		v.AvailabilityZone,
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// Snapshot structure is ebs snapshot information.
+type Snapshot struct {
+	SnapshotId  string
+	Name        string
+	VolumeId    string
+	VolumeSize  int32
+	State       string
+	Encrypted   bool
+	Description string
+	StartTime   time.Time
+}
+
+// DescribeSnapshots returns slice Snapshot structure.
+func (c *EC2) DescribeSnapshots(input *ec2.DescribeSnapshotsInput) ([]Snapshot, error) {
+	list := []Snapshot{}
+	paginator := ec2.NewDescribeSnapshotsPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("describe snapshots: %v", err)
+		}
+
+		for _, s := range output.Snapshots {
+			description := "None"
+			if s.Description != nil && len(*s.Description) > 0 {
+				description = *s.Description
+			}
+
+			list = append(list, Snapshot{
+				SnapshotId:  *s.SnapshotId,
+				Name:        nameTag(s.Tags),
+				VolumeId:    *s.VolumeId,
+				VolumeSize:  *s.VolumeSize,
+				State:       string(s.State),
+				Encrypted:   *s.Encrypted,
+				Description: description,
+				StartTime:   *s.StartTime,
+			})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].StartTime.After(list[j].StartTime)
+	})
+
+	return list, nil
+}
+
+func PrintSnapshots(wrt io.Writer, resources []Snapshot) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"SnapshotId",
+		"Name",
+		"VolumeId",
+		"Size",
+		"State",
+		"Encrypted",
+		"Age",
+		"StartTime",
+		"Description",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.SnapshotTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (s *Snapshot) SnapshotTabString() string {
+	fields := []string{
+		s.SnapshotId,
+		s.Name,
+		s.VolumeId,
+		strconv.Itoa(int(s.VolumeSize)) + "GB",
+		s.State,
+		strconv.FormatBool(s.Encrypted),
+		util.FormatAge(s.StartTime),
+		s.StartTime.String(),
+		s.Description,
+	}
+
+	return strings.Join(fields, "\t")
+}
+
+// Waste structure is a resource reported by EbsWaste.
+type Waste struct {
+	Reason      string
+	ResourceId  string
+	Name        string
+	Detail      string
+	MonthlyCost float64
+}
+
+// unknownVolumeId is the source volume of snapshots created by copy or import.
+const unknownVolumeId = "vol-ffffffff"
+
+// EbsWaste returns unattached volumes, snapshots whose source volume no longer exists
+// and gp2 volumes that could move to gp3, with estimated monthly cost (or savings).
+// Snapshots backing the images, and copied or imported snapshots are not reported.
+func EbsWaste(volumes []Volume, snapshots []Snapshot, images []Image) []Waste {
+	inUse := map[string]bool{}
+	for _, i := range images {
+		for _, s := range i.Snapshots {
+			inUse[s] = true
+		}
+	}
+
+	list := []Waste{}
+	exists := map[string]bool{}
+	for _, v := range volumes {
+		exists[v.VolumeId] = true
+
+		// an unattached volume is reported with its full cost, not the gp3 savings
+		if v.State == "available" {
+			list = append(list, Waste{
+				Reason:      "unattached",
+				ResourceId:  v.VolumeId,
+				Name:        v.Name,
+				Detail:      strconv.Itoa(int(v.Size)) + "GB " + v.VolumeType + ", created " + util.FormatAge(v.CreateTime) + " ago",
+				MonthlyCost: v.MonthlyCost(),
+			})
+			continue
+		}
+
+		if v.VolumeType == "gp2" {
+			list = append(list, Waste{
+				Reason:      "gp2->gp3",
+				ResourceId:  v.VolumeId,
+				Name:        v.Name,
+				Detail:      strconv.Itoa(int(v.Size)) + "GB gp2",
+				MonthlyCost: v.Gp3MonthlySavings(),
+			})
+		}
+	}
+
+	for _, s := range snapshots {
+		if exists[s.VolumeId] || inUse[s.SnapshotId] || s.VolumeId == unknownVolumeId {
+			continue
+		}
+
+		// snapshots are incremental, so the full volume size is the upper bound of the cost
+		list = append(list, Waste{
+			Reason:      "orphaned snapshot",
+			ResourceId:  s.SnapshotId,
+			Name:        s.Name,
+			Detail:      "source " + s.VolumeId + " (" + strconv.Itoa(int(s.VolumeSize)) + "GB), " + util.FormatAge(s.StartTime) + " old",
+			MonthlyCost: float64(s.VolumeSize) * mapping.EbsSnapshotPerGB,
+		})
+	}
+
+	return list
+}
+
+func PrintWaste(wrt io.Writer, resources []Waste) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"Reason",
+		"ResourceId",
+		"Name",
+		"Detail",
+		"Monthly(USD)",
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	waste, savings := 0.0, 0.0
+	for _, r := range resources {
+		if _, err := fmt.Fprintln(w, r.WasteTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+		if r.Reason == "gp2->gp3" {
+			savings += r.MonthlyCost
+		} else {
+			waste += r.MonthlyCost
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, "Total waste\t\t\t\t%.2f\nTotal gp2->gp3 savings\t\t\t\t%.2f\n", waste, savings); err != nil {
+		return fmt.Errorf("resources join: %v", err)
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (i *Waste) WasteTabString() string {
+	fields := []string{
+		i.Reason,
+		i.ResourceId,
+		i.Name,
+		i.Detail,
+		strconv.FormatFloat(i.MonthlyCost, 'f', 2, 64),
+	}
+
+	return strings.Join(fields, "\t")
+}
@@ -0,0 +1,48 @@
+package aws
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEbsWaste(t *testing.T) {
+	volumes := []Volume{
+		{VolumeId: "vol-1", VolumeType: "gp3", Size: 100, State: "in-use"},
+		{VolumeId: "vol-2", VolumeType: "gp3", Size: 50, State: "available"},
+		{VolumeId: "vol-3", VolumeType: "gp2", Size: 100, State: "in-use"},
+		{VolumeId: "vol-4", VolumeType: "gp2", Size: 10, State: "available"},
+	}
+	snapshots := []Snapshot{
+		{SnapshotId: "snap-live", VolumeId: "vol-1", VolumeSize: 100},
+		{SnapshotId: "snap-orphan", VolumeId: "vol-deleted", VolumeSize: 20, StartTime: time.Now()},
+		{SnapshotId: "snap-ami", VolumeId: "vol-deleted", VolumeSize: 8},
+		{SnapshotId: "snap-copy", VolumeId: "vol-ffffffff", VolumeSize: 8},
+	}
+	images := []Image{
+		{ImageId: "ami-1", Snapshots: []string{"snap-ami"}},
+	}
+
+	cases := []struct {
+		reason string
+		id     string
+	}{
+		{"unattached", "vol-2"},
+		{"gp2->gp3", "vol-3"},
+		{"unattached", "vol-4"},
+		{"orphaned snapshot", "snap-orphan"},
+	}
+
+	waste := EbsWaste(volumes, snapshots, images)
+	if len(waste) != len(cases) {
+		t.Fatalf("Waste length should be %d, but got %v", len(cases), waste)
+	}
+
+	for i, c := range cases {
+		if waste[i].Reason != c.reason || waste[i].ResourceId != c.id {
+			t.Errorf("waste[%d] should be %s %s, but got %s %s", i, c.reason, c.id, waste[i].Reason, waste[i].ResourceId)
+		}
+		if waste[i].MonthlyCost <= 0 {
+			t.Errorf("%s cost should be positive, but got %v", c.id, waste[i].MonthlyCost)
+		}
+	}
+}
@@ -0,0 +1,413 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/sfuruya0612/snatch/internal/util"
+)
+
+// Vpc structure is vpc information.
+type Vpc struct {
+	VpcId     string
+	Name      string
+	CidrBlock string
+	State     string
+	IsDefault bool
+}
+
+// DescribeVpcs returns slice Vpc structure.
+func (c *EC2) DescribeVpcs(input *ec2.DescribeVpcsInput) ([]Vpc, error) {
+	list := []Vpc{}
+	paginator := ec2.NewDescribeVpcsPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("describe vpcs: %v", err)
+		}
+
+		for _, v := range output.Vpcs {
+			cidrs := []string{}
+			for _, a := range v.CidrBlockAssociationSet {
+				cidrs = append(cidrs, *a.CidrBlock)
+			}
+			for _, a := range v.Ipv6CidrBlockAssociationSet {
+				cidrs = append(cidrs, *a.Ipv6CidrBlock)
+			}
+
+			list = append(list, Vpc{
+				VpcId:     *v.VpcId,
+				Name:      nameTag(v.Tags),
+				CidrBlock: strings.Join(cidrs, ","),
+				State:     string(v.State),
+				IsDefault: *v.IsDefault,
+			})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list, nil
+}
+
+// Subnet structure is subnet information.
+type Subnet struct {
+	SubnetId                string
+	Name                    string
+	VpcId                   string
+	CidrBlock               string
+	AvailabilityZone        string
+	AvailableIpAddressCount int32
+	MapPublicIpOnLaunch     bool
+}
+
+// DescribeSubnets returns slice Subnet structure.
+func (c *EC2) DescribeSubnets(input *ec2.DescribeSubnetsInput) ([]Subnet, error) {
+	list := []Subnet{}
+	paginator := ec2.NewDescribeSubnetsPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("describe subnets: %v", err)
+		}
+
+		for _, s := range output.Subnets {
+			// IPv6 only subnets have no IPv4 CIDR block
+			cidr := aws.ToString(s.CidrBlock)
+			if len(cidr) == 0 {
+				cidrs := []string{}
+				for _, a := range s.Ipv6CidrBlockAssociationSet {
+					cidrs = append(cidrs, aws.ToString(a.Ipv6CidrBlock))
+				}
+				cidr = strings.Join(cidrs, ",")
+			}
+
+			list = append(list, Subnet{
+				SubnetId:                *s.SubnetId,
+				Name:                    nameTag(s.Tags),
+				VpcId:                   *s.VpcId,
+				CidrBlock:               cidr,
+				AvailabilityZone:        *s.AvailabilityZone,
+				AvailableIpAddressCount: aws.ToInt32(s.AvailableIpAddressCount),
+				MapPublicIpOnLaunch:     aws.ToBool(s.MapPublicIpOnLaunch),
+			})
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].AvailabilityZone+list[i].CidrBlock < list[j].AvailabilityZone+list[j].CidrBlock
+	})
+
+	return list, nil
+}
+
+// Utilization returns available and usable IPv4 address count (AWS reserves 5 per subnet)
+// with used percentage of the subnet, or "None" when the subnet has no IPv4 CIDR block.
+func (s *Subnet) Utilization() string {
+	ip, ipnet, err := net.ParseCIDR(s.CidrBlock)
+	if err != nil || ip.To4() == nil {
+		return "None"
+	}
+
+	ones, bits := ipnet.Mask.Size()
+	total := (1 << (bits - ones)) - 5
+	if total <= 0 {
+		return "None"
+	}
+
+	used := total - int(s.AvailableIpAddressCount)
+
+	return fmt.Sprintf("available %d/%d (%.1f%% used)", s.AvailableIpAddressCount, total, float64(used)/float64(total)*100)
+}
+
+// RouteTable structure is route table information.
+type RouteTable struct {
+	RouteTableId string
+	Name         string
+	VpcId        string
+	Main         bool
+	Subnets      []string
+	Routes       []Route
+}
+
+// Route structure is a route of route table.
+type Route struct {
+	Destination string
+	Target      string
+	State       string
+}
+
+// DescribeRouteTables returns slice RouteTable structure.
+func (c *EC2) DescribeRouteTables(input *ec2.DescribeRouteTablesInput) ([]RouteTable, error) {
+	list := []RouteTable{}
+	paginator := ec2.NewDescribeRouteTablesPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("describe route tables: %v", err)
+		}
+
+		for _, t := range output.RouteTables {
+			rt := RouteTable{
+				RouteTableId: *t.RouteTableId,
+				Name:         nameTag(t.Tags),
+				VpcId:        *t.VpcId,
+			}
+
+			for _, a := range t.Associations {
+				if a.Main != nil && *a.Main {
+					rt.Main = true
+				}
+				if a.SubnetId != nil {
+					rt.Subnets = append(rt.Subnets, *a.SubnetId)
+				}
+			}
+
+			for _, r := range t.Routes {
+				rt.Routes = append(rt.Routes, Route{
+					Destination: routeDestination(r),
+					Target:      routeTarget(r),
+					State:       string(r.State),
+				})
+			}
+
+			list = append(list, rt)
+		}
+	}
+
+	return list, nil
+}
+
+func routeDestination(r types.Route) string {
+	for _, d := range []*string{r.DestinationCidrBlock, r.DestinationIpv6CidrBlock, r.DestinationPrefixListId} {
+		if d != nil {
+			return *d
+		}
+	}
+
+	return "None"
+}
+
+func routeTarget(r types.Route) string {
+	for _, t := range []*string{
+		r.GatewayId,
+		r.NatGatewayId,
+		r.TransitGatewayId,
+		r.VpcPeeringConnectionId,
+		r.NetworkInterfaceId,
+		r.InstanceId,
+		r.EgressOnlyInternetGatewayId,
+		r.LocalGatewayId,
+		r.CarrierGatewayId,
+		r.CoreNetworkArn,
+	} {
+		if t != nil {
+			return *t
+		}
+	}
+
+	return "None"
+}
+
+// IsPublic reports whether the route table has a default route to an internet gateway.
+func (t *RouteTable) IsPublic() bool {
+	for _, r := range t.Routes {
+		if (r.Destination == "0.0.0.0/0" || r.Destination == "::/0") && strings.HasPrefix(r.Target, "igw-") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NatGateway structure is nat gateway information.
+type NatGateway struct {
+	NatGatewayId     string
+	Name             string
+	VpcId            string
+	SubnetId         string
+	State            string
+	ConnectivityType string
+	PublicIps        []string
+}
+
+// DescribeNatGateways returns slice NatGateway structure.
+func (c *EC2) DescribeNatGateways(input *ec2.DescribeNatGatewaysInput) ([]NatGateway, error) {
+	list := []NatGateway{}
+	paginator := ec2.NewDescribeNatGatewaysPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("describe nat gateways: %v", err)
+		}
+
+		for _, n := range output.NatGateways {
+			ips := []string{}
+			for _, a := range n.NatGatewayAddresses {
+				if a.PublicIp != nil {
+					ips = append(ips, *a.PublicIp)
+				}
+			}
+
+			list = append(list, NatGateway{
+				NatGatewayId:     *n.NatGatewayId,
+				Name:             nameTag(n.Tags),
+				VpcId:            *n.VpcId,
+				SubnetId:         *n.SubnetId,
+				State:            string(n.State),
+				ConnectivityType: string(n.ConnectivityType),
+				PublicIps:        ips,
+			})
+		}
+	}
+
+	return list, nil
+}
+
+// VpcEndpoint structure is vpc endpoint information.
+type VpcEndpoint struct {
+	VpcEndpointId string
+	VpcId         string
+	ServiceName   string
+	Type          string
+	State         string
+}
+
+// DescribeVpcEndpoints returns slice VpcEndpoint structure.
+func (c *EC2) DescribeVpcEndpoints(input *ec2.DescribeVpcEndpointsInput) ([]VpcEndpoint, error) {
+	list := []VpcEndpoint{}
+	paginator := ec2.NewDescribeVpcEndpointsPaginator(c.Client, input)
+
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("describe vpc endpoints: %v", err)
+		}
+
+		for _, e := range output.VpcEndpoints {
+			list = append(list, VpcEndpoint{
+				VpcEndpointId: *e.VpcEndpointId,
+				VpcId:         *e.VpcId,
+				ServiceName:   *e.ServiceName,
+				Type:          string(e.VpcEndpointType),
+				State:         string(e.State),
+			})
+		}
+	}
+
+	return list, nil
+}
+
+// VpcTopology structure is the resources of vpcs to build tree view.
+type VpcTopology struct {
+	Vpcs         []Vpc
+	Subnets      []Subnet
+	RouteTables  []RouteTable
+	NatGateways  []NatGateway
+	VpcEndpoints []VpcEndpoint
+}
+
+// BuildVpcTree returns tree view of each vpc.
+func BuildVpcTree(t VpcTopology) []*util.Tree {
+	withName := func(id, name string) string {
+		if len(name) == 0 {
+			return id
+		}
+		return id + " (" + name + ")"
+	}
+
+	trees := []*util.Tree{}
+	for _, v := range t.Vpcs {
+		label := withName(v.VpcId, v.Name) + " " + v.CidrBlock
+		if v.IsDefault {
+			label += " [default]"
+		}
+		root := util.NewTree(label)
+
+		// subnets without explicit association use the main route table
+		var main *RouteTable
+		associated := map[string]*RouteTable{}
+		for i, rt := range t.RouteTables {
+			if rt.VpcId != v.VpcId {
+				continue
+			}
+			if rt.Main {
+				main = &t.RouteTables[i]
+			}
+			for _, s := range rt.Subnets {
+				associated[s] = &t.RouteTables[i]
+			}
+		}
+
+		subnets := root.Add("Subnets")
+		for _, s := range t.Subnets {
+			if s.VpcId != v.VpcId {
+				continue
+			}
+
+			rt := associated[s.SubnetId]
+			if rt == nil {
+				rt = main
+			}
+
+			access := "private"
+			if rt != nil && rt.IsPublic() {
+				access = "public"
+			}
+
+			subnets.Child(s.AvailabilityZone).Add(fmt.Sprintf("%s %s %s %s",
+				withName(s.SubnetId, s.Name), s.CidrBlock, access, s.Utilization()))
+		}
+
+		tables := root.Add("Route Tables")
+		for _, rt := range t.RouteTables {
+			if rt.VpcId != v.VpcId {
+				continue
+			}
+
+			label := withName(rt.RouteTableId, rt.Name)
+			if rt.Main {
+				label += " [main]"
+			}
+			if len(rt.Subnets) > 0 {
+				label += " subnets: " + strings.Join(rt.Subnets, ",")
+			}
+
+			node := tables.Add(label)
+			for _, r := range rt.Routes {
+				node.Add(r.Destination + " -> " + r.Target + " (" + r.State + ")")
+			}
+		}
+
+		nats := root.Add("NAT Gateways")
+		for _, n := range t.NatGateways {
+			if n.VpcId == v.VpcId {
+				nats.Add(withName(n.NatGatewayId, n.Name) + " " + n.SubnetId + " " + n.ConnectivityType + " " + strings.Join(n.PublicIps, ",") + " " + n.State)
+			}
+		}
+
+		endpoints := root.Add("Endpoints")
+		for _, e := range t.VpcEndpoints {
+			if e.VpcId == v.VpcId {
+				endpoints.Add(e.VpcEndpointId + " " + e.ServiceName + " " + e.Type + " " + e.State)
+			}
+		}
+
+		trees = append(trees, root)
+	}
+
+	return trees
+}
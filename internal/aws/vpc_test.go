@@ -0,0 +1,21 @@
+package aws
+
+import "testing"
+
+func TestSubnetUtilization(t *testing.T) {
+	cases := []struct {
+		subnet Subnet
+		want   string
+	}{
+		{Subnet{CidrBlock: "10.0.0.0/24", AvailableIpAddressCount: 241}, "available 241/251 (4.0% used)"},
+		{Subnet{CidrBlock: "10.0.0.0/28", AvailableIpAddressCount: 11}, "available 11/11 (0.0% used)"},
+		{Subnet{CidrBlock: "2600:1f18:abcd:1200::/64"}, "None"},
+		{Subnet{CidrBlock: ""}, "None"},
+	}
+
+	for _, c := range cases {
+		if got := c.subnet.Utilization(); got != c.want {
+			t.Errorf("%s should be %s, but got %s", c.subnet.CidrBlock, c.want, got)
+		}
+	}
+}
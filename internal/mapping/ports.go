@@ -0,0 +1,28 @@
+package mapping
+
+// SensitivePorts are ports that should not be open to the world.
+var SensitivePorts = map[int32]string{
+	20:    "FTP-Data",
+	21:    "FTP",
+	22:    "SSH",
+	23:    "Telnet",
+	25:    "SMTP",
+	135:   "MSRPC",
+	139:   "NetBIOS",
+	445:   "SMB",
+	1433:  "MSSQL",
+	1521:  "Oracle",
+	2049:  "NFS",
+	2375:  "Docker",
+	2379:  "etcd",
+	3306:  "MySQL",
+	3389:  "RDP",
+	5432:  "PostgreSQL",
+	5601:  "Kibana",
+	5900:  "VNC",
+	6379:  "Redis",
+	9092:  "Kafka",
+	9200:  "Elasticsearch",
+	11211: "Memcached",
+	27017: "MongoDB",
+}
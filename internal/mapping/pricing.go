@@ -0,0 +1,29 @@
+package mapping
+
+// EbsPrice is monthly price in USD of EBS (us-east-1).
+// https://aws.amazon.com/ebs/pricing/
+type EbsPrice struct {
+	PerGB         float64
+	PerIops       float64
+	PerThroughput float64
+	// FreeIops and FreeThroughput are included in the storage price (gp3)
+	FreeIops       int32
+	FreeThroughput int32
+}
+
+var EbsPrices = map[string]EbsPrice{
+	"gp2":      {PerGB: 0.10},
+	"gp3":      {PerGB: 0.08, PerIops: 0.005, PerThroughput: 0.04, FreeIops: 3000, FreeThroughput: 125},
+	"io1":      {PerGB: 0.125, PerIops: 0.065},
+	"io2":      {PerGB: 0.125, PerIops: 0.065},
+	"st1":      {PerGB: 0.045},
+	"sc1":      {PerGB: 0.015},
+	"standard": {PerGB: 0.05},
+}
+
+// EbsSnapshotPerGB is monthly price in USD of EBS snapshot standard tier (us-east-1).
+const EbsSnapshotPerGB = 0.05
+
+// PublicIpv4PerHour is hourly price in USD of a public IPv4 address (including idle Elastic IPs).
+// https://aws.amazon.com/vpc/pricing/
+const PublicIpv4PerHour = 0.005
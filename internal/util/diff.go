@@ -0,0 +1,149 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// ChangeType is the kind of difference of a single key.
+type ChangeType string
+
+const (
+	Added    ChangeType = "+"
+	Removed  ChangeType = "-"
+	Modified ChangeType = "~"
+)
+
+// Change structure is a difference of a single key between two maps.
+type Change struct {
+	Key  string
+	Type ChangeType
+	Old  string
+	New  string
+}
+
+// DiffMap returns the changes needed to turn from into to, sorted by key.
+// Keys whose values are equal are not included.
+func DiffMap(from, to map[string]string) []Change {
+	changes := []Change{}
+	for k, v := range to {
+		old, ok := from[k]
+		if !ok {
+			changes = append(changes, Change{Key: k, Type: Added, New: v})
+			continue
+		}
+
+		if old != v {
+			changes = append(changes, Change{Key: k, Type: Modified, Old: old, New: v})
+		}
+	}
+
+	for k, v := range from {
+		if _, ok := to[k]; !ok {
+			changes = append(changes, Change{Key: k, Type: Removed, Old: v})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+
+	return changes
+}
+
+// DiffLines returns a line based diff of a and b.
+// Each line is prefixed with "  " (common), "- " (only in a) or "+ " (only in b).
+func DiffLines(a, b string) []string {
+	x := strings.Split(a, "\n")
+	y := strings.Split(b, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := []string{}
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			lines = append(lines, "  "+x[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, "- "+x[i])
+			i++
+		default:
+			lines = append(lines, "+ "+y[j])
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		lines = append(lines, "- "+x[i])
+	}
+	for ; j < len(y); j++ {
+		lines = append(lines, "+ "+y[j])
+	}
+
+	return lines
+}
+
+func PrintChanges(wrt io.Writer, changes []Change, oldLabel, newLabel string) error {
+	w := tabwriter.NewWriter(wrt, 0, 8, 1, ' ', 0)
+	header := []string{
+		"",
+		"Key",
+		oldLabel,
+		newLabel,
+	}
+
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return fmt.Errorf("header join: %v", err)
+	}
+
+	for _, c := range changes {
+		if _, err := fmt.Fprintln(w, c.ChangeTabString()); err != nil {
+			return fmt.Errorf("resources join: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flush: %v", err)
+	}
+
+	return nil
+}
+
+func (c *Change) ChangeTabString() string {
+	old := c.Old
+	if c.Type == Added {
+		old = "None"
+	}
+
+	new := c.New
+	if c.Type == Removed {
+		new = "None"
+	}
+
+	fields := []string{
+		string(c.Type),
+		c.Key,
+		old,
+		new,
+	}
+
+	return strings.Join(fields, "\t")
+}
@@ -0,0 +1,30 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffMap(t *testing.T) {
+	from := map[string]string{"a": "1", "b": "2", "c": "3"}
+	to := map[string]string{"b": "2", "c": "30", "d": "4"}
+
+	want := []Change{
+		{Key: "a", Type: Removed, Old: "1"},
+		{Key: "c", Type: Modified, Old: "3", New: "30"},
+		{Key: "d", Type: Added, New: "4"},
+	}
+
+	if got := DiffMap(from, to); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffMap should be %v, but got %v", want, got)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	got := DiffLines("a\nb\nc", "a\nc\nd")
+	want := []string{"  a", "- b", "  c", "+ d"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffLines should be %v, but got %v", want, got)
+	}
+}
@@ -1,8 +1,15 @@
 package util
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
 )
 
 func DecodeString(text string) (string, error) {
@@ -13,3 +20,114 @@ func DecodeString(text string) (string, error) {
 
 	return string(d), nil
 }
+
+// DecodeUserData decodes base64 user data.
+// Gzip'd payloads are decompressed and MIME multipart (cloud-init) payloads
+// are split into their parts, each preceded by a header line.
+func DecodeUserData(text string) (string, error) {
+	d, err := DecodeString(text)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := gunzip([]byte(d))
+	if err != nil {
+		return "", err
+	}
+
+	if !isMultipart(b) {
+		return string(b), nil
+	}
+
+	// not every payload with MIME like headers is a valid multipart message
+	out, err := decodeMultipart(b)
+	if err != nil {
+		return string(b), nil
+	}
+
+	return out, nil
+}
+
+// gunzip decompresses b when it starts with the gzip magic number, otherwise returns b as is.
+func gunzip(b []byte) ([]byte, error) {
+	if len(b) < 2 || b[0] != 0x1f || b[1] != 0x8b {
+		return b, nil
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("gzip reader: %v", err)
+	}
+	defer r.Close()
+
+	d, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("gunzip: %v", err)
+	}
+
+	return d, nil
+}
+
+// isMultipart reports whether b starts with a MIME header.
+func isMultipart(b []byte) bool {
+	head := strings.ToLower(string(b[:min(len(b), 64)]))
+	return strings.HasPrefix(head, "content-type:") || strings.HasPrefix(head, "mime-version:")
+}
+
+func decodeMultipart(b []byte) (string, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(b))
+	if err != nil {
+		return "", fmt.Errorf("read mime message: %v", err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return "", fmt.Errorf("parse content type: %v", err)
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return "", fmt.Errorf("not a multipart message: %s", mediaType)
+	}
+
+	var out strings.Builder
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	for n := 1; ; n++ {
+		part, err := r.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("read mime part: %v", err)
+		}
+
+		body, err := io.ReadAll(part)
+		if err != nil {
+			return "", fmt.Errorf("read mime part: %v", err)
+		}
+
+		if strings.EqualFold(part.Header.Get("Content-Transfer-Encoding"), "base64") {
+			d, err := DecodeString(strings.Join(strings.Fields(string(body)), ""))
+			if err != nil {
+				return "", err
+			}
+			body = []byte(d)
+		}
+
+		body, err = gunzip(body)
+		if err != nil {
+			return "", err
+		}
+
+		name := part.FileName()
+		if len(name) == 0 {
+			name = "None"
+		}
+
+		fmt.Fprintf(&out, "--- part %d: %s (filename: %s) ---\n", n, part.Header.Get("Content-Type"), name)
+		out.Write(body)
+		if !bytes.HasSuffix(body, []byte("\n")) {
+			out.WriteString("\n")
+		}
+	}
+
+	return out.String(), nil
+}
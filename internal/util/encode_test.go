@@ -0,0 +1,81 @@
+package util
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func gzipString(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatalf("gzip write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("gzip close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeUserData(t *testing.T) {
+	script := "#!/bin/bash\necho hello\n"
+
+	plain, err := DecodeUserData(base64.StdEncoding.EncodeToString([]byte(script)))
+	if err != nil || plain != script {
+		t.Errorf("plain should be %q, but got %q (%v)", script, plain, err)
+	}
+
+	gz, err := DecodeUserData(base64.StdEncoding.EncodeToString(gzipString(t, script)))
+	if err != nil || gz != script {
+		t.Errorf("gzip should be %q, but got %q (%v)", script, gz, err)
+	}
+
+	// a script that only mentions a multipart content type is not MIME
+	mention := "#!/bin/bash\necho 'Content-Type: multipart/mixed; boundary=x'\n"
+	raw, err := DecodeUserData(base64.StdEncoding.EncodeToString([]byte(mention)))
+	if err != nil || raw != mention {
+		t.Errorf("script should be %q, but got %q (%v)", mention, raw, err)
+	}
+
+	// a broken MIME message falls back to the raw text
+	broken := "Content-Type: multipart/mixed\r\n\r\nbody\r\n"
+	raw, err = DecodeUserData(base64.StdEncoding.EncodeToString([]byte(broken)))
+	if err != nil || raw != broken {
+		t.Errorf("broken mime should be %q, but got %q (%v)", broken, raw, err)
+	}
+
+	mime := strings.Join([]string{
+		`Content-Type: multipart/mixed; boundary="BOUNDARY"`,
+		"MIME-Version: 1.0",
+		"",
+		"--BOUNDARY",
+		`Content-Type: text/cloud-config; charset="us-ascii"`,
+		`Content-Disposition: attachment; filename="cloud-config.txt"`,
+		"",
+		"#cloud-config",
+		"--BOUNDARY",
+		"Content-Type: text/x-shellscript",
+		"Content-Transfer-Encoding: base64",
+		"",
+		base64.StdEncoding.EncodeToString([]byte(script)),
+		"--BOUNDARY--",
+		"",
+	}, "\r\n")
+
+	multi, err := DecodeUserData(base64.StdEncoding.EncodeToString(gzipString(t, mime)))
+	if err != nil {
+		t.Fatalf("Error should be nil, but got %v", err)
+	}
+
+	for _, want := range []string{
+		"--- part 1: text/cloud-config; charset=\"us-ascii\" (filename: cloud-config.txt) ---\n#cloud-config\n",
+		"--- part 2: text/x-shellscript (filename: None) ---\n" + script,
+	} {
+		if !strings.Contains(multi, want) {
+			t.Errorf("multipart should contain %q, but got %q", want, multi)
+		}
+	}
+}
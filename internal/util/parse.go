@@ -3,6 +3,8 @@ package util
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 type Response struct {
@@ -35,3 +37,46 @@ func Marshal(in interface{}) ([]byte, error) {
 
 	return bytes, nil
 }
+
+// Flatten returns the JSON representation of in as a flat map.
+// Nested keys are joined with "." and array elements are suffixed with "[n]",
+// e.g. "BlockDeviceMappings[0].Ebs.VolumeSize". Null values are omitted.
+func Flatten(in interface{}) (map[string]string, error) {
+	bytes, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("json Marshal error: %v", err)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(bytes)))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("json Unmarshal error: %v", err)
+	}
+
+	m := map[string]string{}
+	flatten(m, "", v)
+
+	return m, nil
+}
+
+func flatten(m map[string]string, prefix string, v interface{}) {
+	switch t := v.(type) {
+	case nil:
+	case map[string]interface{}:
+		for k, e := range t {
+			key := k
+			if len(prefix) > 0 {
+				key = prefix + "." + k
+			}
+			flatten(m, key, e)
+		}
+	case []interface{}:
+		for i, e := range t {
+			flatten(m, prefix+"["+strconv.Itoa(i)+"]", e)
+		}
+	default:
+		m[prefix] = fmt.Sprint(t)
+	}
+}
@@ -0,0 +1,39 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	type ebs struct {
+		VolumeSize int
+		Encrypted  *bool
+	}
+	type device struct {
+		DeviceName string
+		Ebs        ebs
+	}
+	in := struct {
+		ImageId             string
+		Monitoring          *bool
+		BlockDeviceMappings []device
+	}{
+		ImageId:             "ami-0123",
+		BlockDeviceMappings: []device{{DeviceName: "/dev/xvda", Ebs: ebs{VolumeSize: 30}}},
+	}
+
+	got, err := Flatten(in)
+	if err != nil {
+		t.Fatalf("Error should be nil, but got %v", err)
+	}
+
+	want := map[string]string{
+		"ImageId":                               "ami-0123",
+		"BlockDeviceMappings[0].DeviceName":     "/dev/xvda",
+		"BlockDeviceMappings[0].Ebs.VolumeSize": "30",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Flatten should be %v, but got %v", want, got)
+	}
+}
@@ -0,0 +1,41 @@
+package util
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline returns values as a line of block characters scaled between their min and max.
+// At most width values are used; longer input is averaged into width buckets.
+func Sparkline(values []float64, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+
+	if len(values) > width {
+		buckets := make([]float64, width)
+		for i := range buckets {
+			from, to := i*len(values)/width, (i+1)*len(values)/width
+			sum := 0.0
+			for _, v := range values[from:to] {
+				sum += v
+			}
+			buckets[i] = sum / float64(to-from)
+		}
+		values = buckets
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+
+	line := make([]rune, len(values))
+	for i, v := range values {
+		n := 0
+		if hi > lo {
+			n = int((v - lo) / (hi - lo) * float64(len(sparks)-1))
+		}
+		line[i] = sparks[n]
+	}
+
+	return string(line)
+}
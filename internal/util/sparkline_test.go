@@ -0,0 +1,22 @@
+package util
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	cases := []struct {
+		values []float64
+		width  int
+		want   string
+	}{
+		{[]float64{1, 2, 3, 4, 5, 6, 7, 8}, 10, "▁▂▃▄▅▆▇█"},
+		{[]float64{3, 3, 3}, 10, "▁▁▁"},
+		{[]float64{1, 1, 8, 8}, 2, "▁█"},
+		{nil, 10, ""},
+	}
+
+	for _, c := range cases {
+		if got := Sparkline(c.values, c.width); got != c.want {
+			t.Errorf("Sparkline(%v, %d) should be %q, but got %q", c.values, c.width, c.want, got)
+		}
+	}
+}
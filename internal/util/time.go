@@ -0,0 +1,42 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration parses duration string like time.ParseDuration,
+// and also accepts days (e.g. 90d) and weeks (e.g. 2w).
+func ParseDuration(s string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil {
+				return 0, fmt.Errorf("parse duration %s: %v", s, err)
+			}
+			return time.Duration(v) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("parse duration %s: %v", s, err)
+	}
+
+	return d, nil
+}
+
+// FormatAge returns elapsed time since t in the largest unit (e.g. 3d, 5h, 10m).
+func FormatAge(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d >= 24*time.Hour:
+		return strconv.Itoa(int(d.Hours()/24)) + "d"
+	case d >= time.Hour:
+		return strconv.Itoa(int(d.Hours())) + "h"
+	default:
+		return strconv.Itoa(int(d.Minutes())) + "m"
+	}
+}
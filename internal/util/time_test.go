@@ -0,0 +1,29 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"90d": 90 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+		"36h": 36 * time.Hour,
+		"15m": 15 * time.Minute,
+	}
+
+	for in, want := range tests {
+		got, err := ParseDuration(in)
+		if err != nil {
+			t.Errorf("%s: Error should be nil, but got %v", in, err)
+		}
+		if got != want {
+			t.Errorf("%s: Duration should be %v, but got %v", in, want, got)
+		}
+	}
+
+	if _, err := ParseDuration("xd"); err == nil {
+		t.Errorf("xd: Error should not be nil")
+	}
+}
@@ -0,0 +1,140 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Tree structure is a node of tree view.
+type Tree struct {
+	Label    string
+	Children []*Tree
+}
+
+// NewTree returns root node of tree view.
+func NewTree(label string) *Tree {
+	return &Tree{Label: label}
+}
+
+// Add appends a new child node and returns it.
+func (t *Tree) Add(label string) *Tree {
+	child := &Tree{Label: label}
+	t.Children = append(t.Children, child)
+	return child
+}
+
+// Child returns the child node with label, adding it when it does not exist.
+func (t *Tree) Child(label string) *Tree {
+	for _, c := range t.Children {
+		if c.Label == label {
+			return c
+		}
+	}
+	return t.Add(label)
+}
+
+// Print writes the tree with box-drawing branches.
+func (t *Tree) Print(wrt io.Writer) error {
+	if _, err := fmt.Fprintln(wrt, t.Label); err != nil {
+		return fmt.Errorf("write tree: %v", err)
+	}
+
+	return t.printChildren(wrt, "")
+}
+
+func (t *Tree) printChildren(wrt io.Writer, prefix string) error {
+	for i, c := range t.Children {
+		branch, indent := "├── ", "│   "
+		if i == len(t.Children)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		if _, err := fmt.Fprintln(wrt, prefix+branch+c.Label); err != nil {
+			return fmt.Errorf("write tree: %v", err)
+		}
+
+		if err := c.printChildren(wrt, prefix+indent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Dot writes the tree as a Graphviz digraph.
+func (t *Tree) Dot(wrt io.Writer) error {
+	if _, err := fmt.Fprintln(wrt, "digraph {\n  rankdir=LR;\n  node [shape=box];"); err != nil {
+		return fmt.Errorf("write dot: %v", err)
+	}
+
+	err := t.walk(func(id int, n *Tree, parent int) error {
+		if _, err := fmt.Fprintf(wrt, "  n%d [label=%s];\n", id, strconv.Quote(n.Label)); err != nil {
+			return err
+		}
+		if parent >= 0 {
+			if _, err := fmt.Fprintf(wrt, "  n%d -> n%d;\n", parent, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("write dot: %v", err)
+	}
+
+	if _, err := fmt.Fprintln(wrt, "}"); err != nil {
+		return fmt.Errorf("write dot: %v", err)
+	}
+
+	return nil
+}
+
+// Mermaid writes the tree as a Mermaid flowchart.
+func (t *Tree) Mermaid(wrt io.Writer) error {
+	if _, err := fmt.Fprintln(wrt, "flowchart LR"); err != nil {
+		return fmt.Errorf("write mermaid: %v", err)
+	}
+
+	err := t.walk(func(id int, n *Tree, parent int) error {
+		// double quotes can not be escaped in mermaid labels
+		label := "n" + strconv.Itoa(id) + "[\"" + strings.ReplaceAll(n.Label, `"`, "#quot;") + "\"]"
+		if parent < 0 {
+			_, err := fmt.Fprintln(wrt, "  "+label)
+			return err
+		}
+		_, err := fmt.Fprintf(wrt, "  n%d --> %s\n", parent, label)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("write mermaid: %v", err)
+	}
+
+	return nil
+}
+
+// walk calls fn for each node in depth first order with sequential ids.
+// parent is -1 for the root node.
+func (t *Tree) walk(fn func(id int, n *Tree, parent int) error) error {
+	next := 0
+
+	var visit func(n *Tree, parent int) error
+	visit = func(n *Tree, parent int) error {
+		id := next
+		next++
+
+		if err := fn(id, n, parent); err != nil {
+			return err
+		}
+
+		for _, c := range n.Children {
+			if err := visit(c, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return visit(t, -1)
+}
@@ -0,0 +1,73 @@
+package util
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTreePrint(t *testing.T) {
+	root := NewTree("/app")
+	prod := root.Child("prod")
+	prod.Add("DB_URL")
+	prod.Child("nested").Add("KEY")
+	root.Child("stg").Add("DB_URL")
+
+	if root.Child("prod") != prod {
+		t.Errorf("Child should return the existing node")
+	}
+
+	var buf bytes.Buffer
+	if err := root.Print(&buf); err != nil {
+		t.Fatalf("Error should be nil, but got %v", err)
+	}
+
+	want := `/app
+├── prod
+│   ├── DB_URL
+│   └── nested
+│       └── KEY
+└── stg
+    └── DB_URL
+`
+	if buf.String() != want {
+		t.Errorf("Tree should be\n%s\nbut got\n%s", want, buf.String())
+	}
+}
+
+func TestTreeGraph(t *testing.T) {
+	root := NewTree("app.example.com")
+	root.Add(`alb "web"`).Add("tg")
+
+	var dot bytes.Buffer
+	if err := root.Dot(&dot); err != nil {
+		t.Fatalf("Error should be nil, but got %v", err)
+	}
+
+	wantDot := `digraph {
+  rankdir=LR;
+  node [shape=box];
+  n0 [label="app.example.com"];
+  n1 [label="alb \"web\""];
+  n0 -> n1;
+  n2 [label="tg"];
+  n1 -> n2;
+}
+`
+	if dot.String() != wantDot {
+		t.Errorf("Dot should be\n%s\nbut got\n%s", wantDot, dot.String())
+	}
+
+	var mermaid bytes.Buffer
+	if err := root.Mermaid(&mermaid); err != nil {
+		t.Fatalf("Error should be nil, but got %v", err)
+	}
+
+	wantMermaid := `flowchart LR
+  n0["app.example.com"]
+  n0 --> n1["alb #quot;web#quot;"]
+  n1 --> n2["tg"]
+`
+	if mermaid.String() != wantMermaid {
+		t.Errorf("Mermaid should be\n%s\nbut got\n%s", wantMermaid, mermaid.String())
+	}
+}
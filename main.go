@@ -15,6 +15,11 @@ var (
 
 var Commands = []*cli.Command{
 	cmd.Ec2,
+	cmd.Vpc,
+	cmd.Asg,
+	cmd.Tag,
+	cmd.Find,
+	cmd.Trace,
 	cmd.Rds,
 	cmd.ElastiCache,
 	cmd.Elb,